@@ -5,7 +5,6 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"path/filepath"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/x/authz"
@@ -17,6 +16,7 @@ import (
 	"github.com/spf13/cast"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	tmcfg "github.com/tendermint/tendermint/config"
 	tmjson "github.com/tendermint/tendermint/libs/json"
 	"github.com/tendermint/tendermint/libs/log"
 	tmos "github.com/tendermint/tendermint/libs/os"
@@ -97,6 +97,7 @@ import (
 
 	apptypes "github.com/akash-network/node/app/types"
 	utypes "github.com/akash-network/node/upgrades/types"
+	"github.com/akash-network/node/x/inflation"
 
 	// unnamed import of statik for swagger UI support
 	_ "github.com/akash-network/node/client/docs/statik"
@@ -359,7 +360,14 @@ func NewApp(
 	// if evidence needs to be handled for the app, set routes in router here and seal
 	app.Keepers.Cosmos.Evidence = *evidenceKeeper
 
-	app.setAkashKeepers()
+	// inflation is calculated from genesis time, nodes must not proceed without it
+	// otherwise minted supply, and therefore app hash, diverges between nodes
+	genesisTime, err := getGenesisTime(appOpts, homePath)
+	if err != nil {
+		panic(fmt.Sprintf("reading genesis time: %s", err))
+	}
+
+	app.setAkashKeepers(genesisTime)
 
 	// NOTE: we may consider parsing `appOpts` inside module constructors. For the moment
 	// we prefer to be more strict in what arguments the modules expect.
@@ -376,9 +384,9 @@ func NewApp(
 			capability.NewAppModule(appCodec, *app.Keepers.Cosmos.Cap),
 			crisis.NewAppModule(&app.Keepers.Cosmos.Crisis, skipGenesisInvariants),
 			gov.NewAppModule(appCodec, app.Keepers.Cosmos.Gov, app.Keepers.Cosmos.Acct, app.Keepers.Cosmos.Bank),
-			mint.NewAppModule(appCodec, app.Keepers.Cosmos.Mint, app.Keepers.Cosmos.Acct),
 			// todo akash-network/support#4
 			// mint.NewAppModule(appCodec, app.Keepers.Cosmos.Mint, app.Keepers.Cosmos.Acct, nil),
+			inflation.NewMintAppModule(appCodec, app.Keepers.Cosmos.Mint, app.Keepers.Cosmos.Acct, app.Keepers.Akash.Inflation.InflationCalculator()),
 			slashing.NewAppModule(appCodec, app.Keepers.Cosmos.Slashing, app.Keepers.Cosmos.Acct, app.Keepers.Cosmos.Bank, app.Keepers.Cosmos.Staking),
 			distr.NewAppModule(appCodec, app.Keepers.Cosmos.Distr, app.Keepers.Cosmos.Acct, app.Keepers.Cosmos.Bank, app.Keepers.Cosmos.Staking),
 			staking.NewAppModule(appCodec, *app.Keepers.Cosmos.Staking, app.Keepers.Cosmos.Acct, app.Keepers.Cosmos.Bank),
@@ -496,22 +504,30 @@ func NewApp(
 	return app
 }
 
-func getGenesisTime(appOpts servertypes.AppOptions, homePath string) time.Time { // nolint: unused,deadcode
+func getGenesisTime(appOpts servertypes.AppOptions, homePath string) (time.Time, error) {
 	if v := appOpts.Get("GenesisTime"); v != nil {
 		// in tests, GenesisTime is supplied using appOpts
 		genTime, ok := v.(time.Time)
 		if !ok {
-			panic("expected GenesisTime to be a Time value")
+			return time.Time{}, fmt.Errorf("expected GenesisTime to be a Time value, got %T", v)
 		}
-		return genTime
+		return genTime, nil
 	}
 
-	genDoc, err := tmtypes.GenesisDocFromFile(filepath.Join(homePath, "config/genesis.json"))
+	// resolve genesis file the way node does, honoring custom genesis_file setting
+	cfg := tmcfg.DefaultBaseConfig()
+	cfg.RootDir = homePath
+
+	if v := cast.ToString(appOpts.Get("genesis_file")); v != "" {
+		cfg.Genesis = v
+	}
+
+	genDoc, err := tmtypes.GenesisDocFromFile(cfg.GenesisFile())
 	if err != nil {
-		panic(err)
+		return time.Time{}, err
 	}
 
-	return genDoc.GenesisTime
+	return genDoc.GenesisTime, nil
 }
 
 // MakeCodecs constructs the *std.Codec and *codec.LegacyAmino instances used by
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/types/module"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
@@ -74,11 +76,12 @@ func akashSubspaces(k paramskeeper.Keeper) paramskeeper.Keeper {
 	k.Subspace(astaking.ModuleName)
 	k.Subspace(agov.ModuleName)
 	k.Subspace(take.ModuleName)
+	k.Subspace(escrow.ModuleName)
 
 	return k
 }
 
-func (app *AkashApp) setAkashKeepers() {
+func (app *AkashApp) setAkashKeepers(genesisTime time.Time) {
 	app.Keepers.Akash.Take = tkeeper.NewKeeper(
 		app.appCodec,
 		app.skeys[take.ModuleName],
@@ -88,6 +91,7 @@ func (app *AkashApp) setAkashKeepers() {
 	app.Keepers.Akash.Escrow = ekeeper.NewKeeper(
 		app.appCodec,
 		app.skeys[escrow.ModuleName],
+		app.GetSubspace(escrow.ModuleName),
 		app.Keepers.Cosmos.Bank,
 		app.Keepers.Akash.Take,
 		app.Keepers.Cosmos.Distr,
@@ -135,6 +139,8 @@ func (app *AkashApp) setAkashKeepers() {
 		app.appCodec,
 		app.skeys[inflation.ModuleName],
 		app.GetSubspace(inflation.ModuleName),
+		app.Keepers.Cosmos.Mint,
+		genesisTime,
 	)
 
 	app.Keepers.Akash.Staking = astakingkeeper.NewKeeper(
@@ -160,6 +166,7 @@ func (app *AkashApp) akashAppModules() []module.AppModule {
 		escrow.NewAppModule(
 			app.appCodec,
 			app.Keepers.Akash.Escrow,
+			app.Keepers.Cosmos.Bank,
 		),
 
 		deployment.NewAppModule(
@@ -186,6 +193,8 @@ func (app *AkashApp) akashAppModules() []module.AppModule {
 			app.Keepers.Akash.Provider,
 			app.Keepers.Cosmos.Bank,
 			app.Keepers.Akash.Market,
+			app.Keepers.Akash.Escrow,
+			app.Keepers.Akash.Deployment,
 		),
 
 		audit.NewAppModule(
@@ -298,7 +307,6 @@ func (app *AkashApp) akashInitGenesisOrder() []string {
 		audittypes.ModuleName,
 		upgradetypes.ModuleName,
 		minttypes.ModuleName,
-		crisistypes.ModuleName,
 		ibchost.ModuleName,
 		evidencetypes.ModuleName,
 		transfertypes.ModuleName,
@@ -312,6 +320,8 @@ func (app *AkashApp) akashInitGenesisOrder() []string {
 		inflation.ModuleName,
 		astaking.ModuleName,
 		agov.ModuleName,
+		// crisis asserts invariants on genesis, it goes after all modules they cover
+		crisistypes.ModuleName,
 		genutiltypes.ModuleName,
 	}
 }
@@ -322,6 +332,14 @@ func (app *AkashApp) akashSimModules() []module.AppModuleSimulation {
 			app.Keepers.Akash.Take,
 		),
 
+		escrow.NewAppModuleSimulation(
+			app.Keepers.Akash.Escrow,
+			app.Keepers.Cosmos.Acct,
+			app.Keepers.Akash.Deployment,
+			app.Keepers.Akash.Market,
+			app.Keepers.Cosmos.Bank,
+		),
+
 		deployment.NewAppModuleSimulation(
 			app.Keepers.Akash.Deployment,
 			app.Keepers.Cosmos.Acct,
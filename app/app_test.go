@@ -3,10 +3,14 @@ package app
 import (
 	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -40,3 +44,28 @@ func TestAppExport(t *testing.T) {
 	_, err = app2.ExportAppStateAndValidators(false, []string{})
 	require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
 }
+
+func TestGetGenesisTime(t *testing.T) {
+	home := t.TempDir()
+	genTime := time.Date(2021, 3, 8, 15, 0, 0, 0, time.UTC)
+
+	genDoc := &tmtypes.GenesisDoc{
+		ChainID:     "akash-test",
+		GenesisTime: genTime,
+	}
+
+	// genesis file outside of home config dir is found only through genesis_file setting
+	genFile := filepath.Join(home, "custom-genesis.json")
+	require.NoError(t, genDoc.SaveAs(genFile))
+
+	appOpts := viper.New()
+
+	_, err := getGenesisTime(appOpts, home)
+	require.Error(t, err)
+
+	appOpts.Set("genesis_file", "custom-genesis.json")
+
+	res, err := getGenesisTime(appOpts, home)
+	require.NoError(t, err)
+	require.True(t, genTime.Equal(res))
+}
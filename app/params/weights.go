@@ -13,4 +13,9 @@ const (
 	DefaultWeightMsgCreateBid  int = 100
 	DefaultWeightMsgCloseBid   int = 100
 	DefaultWeightMsgCloseLease int = 10
+
+	DefaultWeightMsgDepositDeployment  int = 50
+	DefaultWeightMsgCreateLease        int = 100
+	DefaultWeightMsgWithdrawLease      int = 50
+	DefaultWeightMsgCloseEscrowAccount int = 10
 )
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/akash-network/node/app"
+)
+
+var flagInvariantsModule = "module"
+
+// CheckInvariantsCmd get cmd to assert registered invariants against application state in the data dir.
+func CheckInvariantsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "check-invariants [data-dir]",
+		Short: "Assert registered invariants against application state of a stopped node",
+		Long: `Assert invariants registered with the crisis module against application state
+at the latest committed height. Data dir defaults to <home>/data and node must not be running.
+Example:
+	akash debug check-invariants
+	akash debug check-invariants ~/.akash/data --module market
+	`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sctx := server.GetServerContextFromCmd(cmd)
+
+			home, err := cmd.Flags().GetString(flags.FlagHome)
+			if err != nil {
+				return err
+			}
+
+			modules, err := cmd.Flags().GetStringSlice(flagInvariantsModule)
+			if err != nil {
+				return err
+			}
+
+			dataDir := filepath.Join(home, "data")
+			if len(args) > 0 {
+				dataDir = args[0]
+			}
+
+			db, err := sdk.NewLevelDB("application", dataDir)
+			if err != nil {
+				return err
+			}
+
+			defer func() {
+				_ = db.Close()
+			}()
+
+			akashApp := app.NewApp(sctx.Logger, db, nil, true, 0, map[int64]bool{}, home, sctx.Viper)
+			height := akashApp.LastBlockHeight()
+			if height == 0 {
+				return fmt.Errorf("no committed state found in %s", dataDir)
+			}
+
+			ctx := akashApp.NewUncachedContext(false, tmproto.Header{Height: height})
+
+			filter := make(map[string]bool, len(modules))
+			for _, module := range modules {
+				filter[module] = true
+			}
+
+			broken := 0
+			for _, route := range akashApp.Keepers.Cosmos.Crisis.Routes() {
+				if len(filter) > 0 && !filter[route.ModuleName] {
+					continue
+				}
+
+				res, stop := route.Invar(ctx)
+				if !stop {
+					cmd.Printf("%s: ok\n", route.FullRoute())
+					continue
+				}
+
+				broken++
+				cmd.Printf("%s: broken\n%s", route.FullRoute(), res)
+			}
+
+			if broken > 0 {
+				return fmt.Errorf("%d invariants broken at height %d", broken, height)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSlice(flagInvariantsModule, nil, "Check invariants of given modules only")
+
+	return cmd
+}
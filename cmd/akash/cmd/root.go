@@ -139,6 +139,7 @@ func initRootCmd(rootCmd *cobra.Command, encodingConfig params.EncodingConfig) {
 	debugCmd := debug.Cmd()
 	debugCmd.AddCommand(ConvertBech32Cmd())
 	debugCmd.AddCommand(testnetify.Cmd())
+	debugCmd.AddCommand(CheckInvariantsCmd())
 
 	rootCmd.AddCommand(
 		rpc.StatusCommand(),
@@ -224,13 +225,13 @@ func createAppAndExport(
 	var akashApp *app.AkashApp
 
 	if height != -1 {
-		akashApp = app.NewApp(logger, db, tio, false, uint(1), map[int64]bool{}, "", appOpts)
+		akashApp = app.NewApp(logger, db, tio, false, uint(1), map[int64]bool{}, cast.ToString(appOpts.Get(flags.FlagHome)), appOpts)
 
 		if err := akashApp.LoadHeight(height); err != nil {
 			return servertypes.ExportedApp{}, err
 		}
 	} else {
-		akashApp = app.NewApp(logger, db, tio, true, uint(1), map[int64]bool{}, "", appOpts)
+		akashApp = app.NewApp(logger, db, tio, true, uint(1), map[int64]bool{}, cast.ToString(appOpts.Get(flags.FlagHome)), appOpts)
 	}
 
 	return akashApp.ExportAppStateAndValidators(forZeroHeight, jailAllowedAddrs)
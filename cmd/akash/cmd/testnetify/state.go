@@ -138,13 +138,13 @@ type DeploymentState struct {
 
 type EscrowState struct {
 	gstate map[string]json.RawMessage
-	state  *etypes.GenesisState
+	state  *escrow.GenesisState
 	once   sync.Once
 }
 
 type MarketState struct {
 	gstate map[string]json.RawMessage
-	state  *mtypes.GenesisState
+	state  *market.GenesisState
 	once   sync.Once
 }
 
@@ -669,7 +669,7 @@ func (ga *EscrowState) unpack(cdc codec.Codec) error {
 
 func (ga *EscrowState) pack(cdc codec.Codec) error {
 	if ga.state != nil {
-		stateBz, err := cdc.MarshalJSON(ga.state)
+		stateBz, err := escrow.MarshalGenesis(cdc, ga.state)
 		if err != nil {
 			return fmt.Errorf("failed to marshal escrow genesis state: %s", err.Error()) // nolint: goerr113
 		}
@@ -693,7 +693,7 @@ func (ga *MarketState) unpack(cdc codec.Codec) error {
 
 func (ga *MarketState) pack(cdc codec.Codec) error {
 	if ga.state != nil {
-		stateBz, err := cdc.MarshalJSON(ga.state)
+		stateBz, err := market.MarshalGenesis(cdc, ga.state)
 		if err != nil {
 			return fmt.Errorf("failed to marshal market genesis state: %s", err.Error()) // nolint: goerr113
 		}
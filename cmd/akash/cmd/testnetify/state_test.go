@@ -0,0 +1,74 @@
+package testnetify
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	"github.com/akash-network/node/testutil/state"
+	"github.com/akash-network/node/x/escrow"
+	ekeeper "github.com/akash-network/node/x/escrow/keeper"
+	"github.com/akash-network/node/x/market"
+	mkeeper "github.com/akash-network/node/x/market/keeper"
+)
+
+func TestEscrowStateRoundTrip(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+	ctx := suite.Context()
+	cdc := suite.App().AppCodec()
+
+	params := ekeeper.Params{
+		SettlementBlockBudget: 7,
+		SettlementPeriod:      200,
+	}
+	suite.EscrowKeeper().SetParams(ctx, params)
+
+	bz, err := escrow.MarshalGenesis(cdc, escrow.ExportGenesis(ctx, suite.EscrowKeeper()))
+	require.NoError(t, err)
+
+	gstate := map[string]json.RawMessage{
+		etypes.ModuleName: bz,
+	}
+
+	st := &EscrowState{gstate: gstate}
+	require.NoError(t, st.unpack(cdc))
+	require.Equal(t, params, st.state.Params)
+	require.NoError(t, st.pack(cdc))
+
+	data, err := escrow.UnmarshalGenesis(cdc, gstate[etypes.ModuleName])
+	require.NoError(t, err)
+	require.Equal(t, params, data.Params)
+}
+
+func TestMarketStateRoundTrip(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+	ctx := suite.Context()
+	cdc := suite.App().AppCodec()
+
+	params := mkeeper.ExpirationParams{
+		OrderTTL:              300,
+		BidTTL:                30,
+		ExpirationBlockBudget: 9,
+	}
+	suite.MarketKeeper().SetExpirationParams(ctx, params)
+
+	bz, err := market.MarshalGenesis(cdc, market.ExportGenesis(ctx, suite.MarketKeeper()))
+	require.NoError(t, err)
+
+	gstate := map[string]json.RawMessage{
+		mtypes.ModuleName: bz,
+	}
+
+	st := &MarketState{gstate: gstate}
+	require.NoError(t, st.unpack(cdc))
+	require.Equal(t, params, st.state.ExpirationParams)
+	require.NoError(t, st.pack(cdc))
+
+	data, err := market.UnmarshalGenesis(cdc, gstate[mtypes.ModuleName])
+	require.NoError(t, err)
+	require.Equal(t, params, data.ExpirationParams)
+}
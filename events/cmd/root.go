@@ -1,12 +1,14 @@
-package cmd and alpha
-
+package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -18,11 +20,30 @@ import (
 	"github.com/akash-network/node/pubsub"
 )
 
+const (
+	flagFromHeight = "from-height"
+	flagToHeight   = "to-height"
+	flagOwner      = "owner"
+	flagDSeq       = "dseq"
+	flagProvider   = "provider"
+	flagAction     = "action"
+	flagFormat     = "format"
+
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+)
+
+var errInvalidFormat = errors.New("invalid output format")
+
 // EventCmd prints out events in real time
 func EventCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "events",
 		Short: "Prints out akash events in real time",
+		Long: `Prints out akash events in real time.
+
+With --from-height events of past blocks are replayed first, and streaming
+continues live unless --to-height is set too.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return common.RunForeverWithContext(cmd.Context(), func(ctx context.Context) error {
 				return getEvents(ctx, cmd, args)
@@ -30,34 +51,72 @@ func EventCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().String(flags.FlagNode, "tcp://localhost:26657", "The node address")
-	if err := viper.BindPFlag(flags.FlagNode, cmd.Flags().Lookup(flags.FlagNode)); err != nil {
+	cmd.PersistentFlags().String(flags.FlagNode, "tcp://localhost:26657", "The node address")
+	if err := viper.BindPFlag(flags.FlagNode, cmd.PersistentFlags().Lookup(flags.FlagNode)); err != nil {
 		return nil
 	}
 
+	cmd.Flags().Int64(flagFromHeight, 0, "Replay events starting from the block height")
+	cmd.Flags().Int64(flagToHeight, 0, "Replay events up to the block height and exit. Requires --from-height")
+	cmd.Flags().String(flagOwner, "", "Print events of the owner only")
+	cmd.Flags().Uint64(flagDSeq, 0, "Print events of the deployment sequence only")
+	cmd.Flags().String(flagProvider, "", "Print events of the provider only")
+	cmd.Flags().StringArray(flagAction, nil, "Print events of the action only, e.g. lease-created. Can be repeated")
+	cmd.Flags().String(flagFormat, formatJSON, "Output format (json|ndjson)")
+
+	cmd.AddCommand(ServeCmd())
+
 	return cmd
 }
 
 func getEvents(ctx context.Context, cmd *cobra.Command, _ []string) error {
 	cctx := client.GetClientContextFromCmd(cmd)
 
+	printer, err := eventPrinterFromFlags(cmd, cctx)
+	if err != nil {
+		return err
+	}
+
+	fromHeight, err := cmd.Flags().GetInt64(flagFromHeight)
+	if err != nil {
+		return err
+	}
+
+	toHeight, err := cmd.Flags().GetInt64(flagToHeight)
+	if err != nil {
+		return err
+	}
+
+	if toHeight > 0 && fromHeight <= 0 {
+		return fmt.Errorf("--%s requires --%s", flagToHeight, flagFromHeight)
+	}
+
 	if err := cctx.Client.Start(); err != nil {
 		return err
 	}
 
-	bus := pubsub.NewBus()
+	if toHeight > 0 {
+		err = events.Replay(ctx, cctx.Client, fromHeight, toHeight, printer)
+		if err != nil && !errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		return nil
+	}
+
+	bus := pubsub.NewBus(pubsub.WithName("events-cli"))
 	defer bus.Close()
 
 	group, ctx := errgroup.WithContext(ctx)
 
-	subscriber, err := bus.Subscribe()
+	subscriber, err := bus.SubscribeWithFilter(printer.filter.Accept)
 
 	if err != nil {
 		return err
 	}
 
 	group.Go(func() error {
-		return events.Publish(ctx, cctx.Client, "akash-cli", bus)
+		return events.Publish(ctx, cctx.Client, "akash-cli", bus, events.WithStartHeight(fromHeight))
 	})
 
 	group.Go(func() error {
@@ -66,7 +125,7 @@ func getEvents(ctx context.Context, cmd *cobra.Command, _ []string) error {
 			case <-subscriber.Done():
 				return nil
 			case ev := <-subscriber.Events():
-				if err := cmdcommon.PrintJSON(cctx, ev); err != nil {
+				if err := printer.print(ev); err != nil {
 					return err
 				}
 			}
@@ -80,3 +139,79 @@ func getEvents(ctx context.Context, cmd *cobra.Command, _ []string) error {
 
 	return nil
 }
+
+// eventPrinter prints events matching the filter
+type eventPrinter struct {
+	cctx   client.Context
+	filter events.Filter
+	format string
+}
+
+func eventPrinterFromFlags(cmd *cobra.Command, cctx client.Context) (eventPrinter, error) {
+	printer := eventPrinter{
+		cctx: cctx,
+	}
+
+	var err error
+
+	if printer.format, err = cmd.Flags().GetString(flagFormat); err != nil {
+		return printer, err
+	}
+
+	if printer.format != formatJSON && printer.format != formatNDJSON {
+		return printer, fmt.Errorf("%w: %q", errInvalidFormat, printer.format)
+	}
+
+	if printer.filter.Owner, err = addressFromFlag(cmd, flagOwner); err != nil {
+		return printer, err
+	}
+
+	if printer.filter.Provider, err = addressFromFlag(cmd, flagProvider); err != nil {
+		return printer, err
+	}
+
+	if printer.filter.DSeq, err = cmd.Flags().GetUint64(flagDSeq); err != nil {
+		return printer, err
+	}
+
+	if printer.filter.Actions, err = cmd.Flags().GetStringArray(flagAction); err != nil {
+		return printer, err
+	}
+
+	return printer, nil
+}
+
+// Publish prints event if it matches the filter. Implements pubsub.Publisher for replay
+func (p eventPrinter) Publish(ev pubsub.Event) error {
+	if !p.filter.Accept(ev) {
+		return nil
+	}
+
+	return p.print(ev)
+}
+
+func (p eventPrinter) print(ev pubsub.Event) error {
+	if p.format != formatNDJSON {
+		return cmdcommon.PrintJSON(p.cctx, ev)
+	}
+
+	marshaled, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	return p.cctx.PrintString(string(marshaled) + "\n")
+}
+
+func addressFromFlag(cmd *cobra.Command, flag string) (string, error) {
+	val, err := cmd.Flags().GetString(flag)
+	if err != nil || val == "" {
+		return val, err
+	}
+
+	if _, err := sdk.AccAddressFromBech32(val); err != nil {
+		return "", fmt.Errorf("--%s: %w", flag, err)
+	}
+
+	return val, nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/akash-network/node/cmd/common"
+	"github.com/akash-network/node/events"
+	"github.com/akash-network/node/events/gateway"
+	"github.com/akash-network/node/pubsub"
+)
+
+const (
+	flagListen      = "listen"
+	flagBufferLimit = "buffer-limit"
+	flagMaxReplay   = "max-replay"
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// ServeCmd serves akash events to websocket and SSE clients
+func ServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serves akash events to websocket and server sent events clients",
+		Long: `Serves akash events to websocket and server sent events clients.
+
+Streams are served at /ws and /sse and filtered by query parameters owner, dseq,
+provider and action. Every event carries its position, which passed back as the
+after parameter (or Last-Event-ID header of SSE) resumes the stream after it.
+Streams are resumed from positions within --max-replay blocks only.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return common.RunForeverWithContext(cmd.Context(), func(ctx context.Context) error {
+				return serveEvents(ctx, cmd)
+			})
+		},
+	}
+
+	cmd.Flags().String(flagListen, "localhost:8088", "Address to serve events at")
+	cmd.Flags().Int(flagBufferLimit, 1000, "Number of events buffered for a client before it is disconnected")
+	cmd.Flags().Int64(flagMaxReplay, 1000, "Number of past blocks replayed to a client resuming a stream at most")
+
+	return cmd
+}
+
+func serveEvents(ctx context.Context, cmd *cobra.Command) error {
+	cctx := client.GetClientContextFromCmd(cmd)
+
+	listen, err := cmd.Flags().GetString(flagListen)
+	if err != nil {
+		return err
+	}
+
+	bufferLimit, err := cmd.Flags().GetInt(flagBufferLimit)
+	if err != nil {
+		return err
+	}
+
+	maxReplay, err := cmd.Flags().GetInt64(flagMaxReplay)
+	if err != nil {
+		return err
+	}
+
+	if err := cctx.Client.Start(); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return err
+	}
+
+	bus := pubsub.NewBus(pubsub.WithName("events-gateway"))
+	defer bus.Close()
+
+	gw := gateway.NewServer(bus, cctx.Client, gateway.WithBufferLimit(bufferLimit), gateway.WithMaxReplay(maxReplay))
+
+	srv := &http.Server{
+		Handler:           gw.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+
+	group.Go(func() error {
+		return events.Publish(ctx, cctx.Client, "akash-gateway", bus, events.WithEnvelope())
+	})
+
+	group.Go(func() error {
+		err := srv.Serve(listener)
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	})
+
+	group.Go(func() error {
+		<-ctx.Done()
+
+		// closing the bus ends streams of connected clients
+		bus.Close()
+
+		sctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		return srv.Shutdown(sctx)
+	})
+
+	err = group.Wait()
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
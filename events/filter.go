@@ -0,0 +1,141 @@
+package events
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	atypes "github.com/akash-network/akash-api/go/node/audit/v1beta3"
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	ptypes "github.com/akash-network/akash-api/go/node/provider/v1beta3"
+	"github.com/akash-network/akash-api/go/sdkutil"
+
+	"github.com/akash-network/node/pubsub"
+	escrowtypes "github.com/akash-network/node/x/escrow/types"
+)
+
+const (
+	evOwnerKey     = "owner"
+	evDSeqKey      = "dseq"
+	evProviderKey  = "provider"
+	evPaymentIDKey = "payment-id"
+)
+
+// Filter matches events by their action and the owner, deployment and provider they refer to.
+// Zero value fields match any event, events which do not refer to a filtered field never match.
+type Filter struct {
+	Owner    string
+	DSeq     uint64
+	Provider string
+	// Actions of matching events, e.g. "lease-created"
+	Actions []string
+}
+
+// Empty returns true if filter matches any event
+func (f Filter) Empty() bool {
+	return f.Owner == "" && f.DSeq == 0 && f.Provider == "" && len(f.Actions) == 0
+}
+
+// Accept returns true if event, or event of an Envelope, matches the filter.
+// Subject of envelopes published by Publish is extracted once when published,
+// other events are parsed on every call
+func (f Filter) Accept(ev pubsub.Event) bool {
+	if f.Empty() {
+		return true
+	}
+
+	if env, ok := ev.(Envelope); ok {
+		if env.subject != nil {
+			return f.accept(*env.subject)
+		}
+
+		ev = env.Event
+	}
+
+	mev, ok := ev.(sdkutil.ModuleEvent)
+	if !ok {
+		return false
+	}
+
+	sev, err := sdkutil.ParseEvent(sdk.StringifyEvent(abci.Event(mev.ToSDKEvent())))
+	if err != nil {
+		return false
+	}
+
+	return f.accept(newEventSubject(sev))
+}
+
+func (f Filter) accept(subj eventSubject) bool {
+	if len(f.Actions) > 0 && !containsString(f.Actions, subj.action) {
+		return false
+	}
+
+	if f.Owner != "" && subj.owner != f.Owner {
+		return false
+	}
+
+	if f.DSeq != 0 && subj.dseq != f.DSeq {
+		return false
+	}
+
+	if f.Provider != "" && subj.provider != f.Provider {
+		return false
+	}
+
+	return true
+}
+
+// eventSubject is the action of event and the owner, deployment and provider it refers to
+type eventSubject struct {
+	action   string
+	owner    string
+	dseq     uint64
+	provider string
+}
+
+func newEventSubject(ev sdkutil.Event) eventSubject {
+	subj := eventSubject{
+		action: ev.Action,
+	}
+
+	switch ev.Module {
+	case ptypes.ModuleName, atypes.ModuleName:
+		// owner of provider and audit events is the provider
+		subj.provider, _ = sdkutil.GetString(ev.Attributes, evOwnerKey)
+	case etypes.ModuleName:
+		// owner of escrow events is owner of account or payment,
+		// deployment and lease are identified by escrow ids
+		id, err := escrowtypes.ParseEVAccountID(ev.Attributes)
+		if err != nil {
+			break
+		}
+
+		if did, valid := dtypes.DeploymentIDFromEscrowAccount(id); valid {
+			subj.owner = did.Owner
+			subj.dseq = did.DSeq
+		}
+
+		if pid, err := sdkutil.GetString(ev.Attributes, evPaymentIDKey); err == nil {
+			if lid, valid := mtypes.LeaseIDFromEscrowAccount(id, pid); valid {
+				subj.provider = lid.Provider
+			}
+		}
+	default:
+		subj.owner, _ = sdkutil.GetString(ev.Attributes, evOwnerKey)
+		subj.dseq, _ = sdkutil.GetUint64(ev.Attributes, evDSeqKey)
+		subj.provider, _ = sdkutil.GetString(ev.Attributes, evProviderKey)
+	}
+
+	return subj
+}
+
+func containsString(vals []string, val string) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,120 @@
+package events
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	ptypes "github.com/akash-network/akash-api/go/node/provider/v1beta3"
+	"github.com/akash-network/akash-api/go/sdkutil"
+
+	"github.com/akash-network/node/pubsub"
+	"github.com/akash-network/node/testutil"
+	escrowtypes "github.com/akash-network/node/x/escrow/types"
+)
+
+func TestFilterAccept(t *testing.T) {
+	lid := testutil.LeaseID(t)
+	provider, err := sdk.AccAddressFromBech32(lid.Provider)
+	require.NoError(t, err)
+
+	did := lid.DeploymentID()
+	other := testutil.LeaseID(t)
+
+	deploymentCreated := dtypes.NewEventDeploymentCreated(did, testutil.DeploymentVersion(t))
+	leaseCreated := mtypes.NewEventLeaseCreated(lid, testutil.DecCoin(t))
+	otherLeaseCreated := mtypes.NewEventLeaseCreated(other, testutil.DecCoin(t))
+	providerUpdated := ptypes.NewEventProviderUpdated(provider)
+	paymentWithdrawn := escrowtypes.NewEventPaymentWithdrawn(
+		dtypes.EscrowAccountForDeployment(did),
+		mtypes.EscrowPaymentForLease(lid),
+		provider,
+		testutil.AkashCoin(t, 100),
+	)
+
+	evs := []sdkutil.ModuleEvent{deploymentCreated, leaseCreated, otherLeaseCreated, providerUpdated, paymentWithdrawn}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []sdkutil.ModuleEvent
+	}{
+		{
+			name:     "empty",
+			expected: evs,
+		},
+		{
+			name:     "owner",
+			filter:   Filter{Owner: did.Owner},
+			expected: []sdkutil.ModuleEvent{deploymentCreated, leaseCreated, paymentWithdrawn},
+		},
+		{
+			name:     "deployment",
+			filter:   Filter{Owner: did.Owner, DSeq: did.DSeq},
+			expected: []sdkutil.ModuleEvent{deploymentCreated, leaseCreated, paymentWithdrawn},
+		},
+		{
+			name:     "dseq of other deployment",
+			filter:   Filter{Owner: did.Owner, DSeq: did.DSeq + 1},
+			expected: []sdkutil.ModuleEvent{},
+		},
+		{
+			name:     "provider",
+			filter:   Filter{Provider: lid.Provider},
+			expected: []sdkutil.ModuleEvent{leaseCreated, providerUpdated, paymentWithdrawn},
+		},
+		{
+			name:     "action",
+			filter:   Filter{Actions: []string{"lease-created", "payment-withdrawn"}},
+			expected: []sdkutil.ModuleEvent{leaseCreated, otherLeaseCreated, paymentWithdrawn},
+		},
+		{
+			name:     "action and owner",
+			filter:   Filter{Owner: did.Owner, Actions: []string{"lease-created"}},
+			expected: []sdkutil.ModuleEvent{leaseCreated},
+		},
+	}
+
+	// envelopes carry subject extracted when published
+	envs := make([]Envelope, 0, len(evs))
+	p := &publisher{
+		opts: publishOptions{envelope: true},
+		pub: publisherFunc(func(ev pubsub.Event) error {
+			envs = append(envs, ev.(Envelope))
+			return nil
+		}),
+	}
+	require.NoError(t, p.publishEvents(1, 0, abciEvents(evs...)))
+	require.Len(t, envs, len(evs))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accepted := []sdkutil.ModuleEvent{}
+			acceptedEnvs := []sdkutil.ModuleEvent{}
+
+			for idx, ev := range evs {
+				if tt.filter.Accept(ev) {
+					accepted = append(accepted, ev)
+				}
+
+				require.NotNil(t, envs[idx].subject)
+				if tt.filter.Accept(envs[idx]) {
+					acceptedEnvs = append(acceptedEnvs, ev)
+				}
+			}
+
+			assert.Equal(t, tt.expected, accepted)
+			assert.Equal(t, tt.expected, acceptedEnvs)
+		})
+	}
+}
+
+type publisherFunc func(pubsub.Event) error
+
+func (fn publisherFunc) Publish(ev pubsub.Event) error {
+	return fn(ev)
+}
@@ -0,0 +1,372 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"github.com/akash-network/node/events"
+	"github.com/akash-network/node/pubsub"
+	"github.com/akash-network/node/util/wsutil"
+)
+
+const (
+	// FrameEvent is id of websocket frames carrying JSON encoded events.Envelope
+	FrameEvent byte = 1
+	// FrameError is id of websocket frame carrying error the stream has been closed with
+	FrameError byte = 2
+
+	// headerLastEventID is the header SSE clients resume streams with
+	headerLastEventID = "Last-Event-ID"
+
+	defaultBufferLimit = 1000
+	defaultMaxReplay   = 1000
+)
+
+var (
+	// ErrInvalidRequest is the error returned for requests with invalid filters or resume token
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrResumeTooOld is the error returned for resume tokens older than the server replays
+	ErrResumeTooOld = errors.New("resume token too old")
+)
+
+// Server streams events of the bus to websocket and SSE clients.
+// Bus is expected to carry events.Envelope, as published by events.Publish with events.WithEnvelope.
+type Server struct {
+	bus         pubsub.Bus
+	blocks      events.BlockResultsClient
+	bufferLimit int
+	maxReplay   int64
+	upgrader    websocket.Upgrader
+}
+
+// Option configures server
+type Option func(*Server)
+
+// WithBufferLimit sets number of events buffered for a client. Client that
+// falls behind by more events is disconnected and has to resume
+func WithBufferLimit(limit int) Option {
+	return func(s *Server) {
+		s.bufferLimit = limit
+	}
+}
+
+// WithMaxReplay sets number of past blocks replayed to clients resuming a stream at most.
+// Resuming from an older token fails with ErrResumeTooOld
+func WithMaxReplay(blocks int64) Option {
+	return func(s *Server) {
+		s.maxReplay = blocks
+	}
+}
+
+// NewServer returns server streaming events of the bus. Client is used to replay
+// events of past blocks to clients resuming a stream
+func NewServer(bus pubsub.Bus, client events.BlockResultsClient, opts ...Option) *Server {
+	s := &Server{
+		bus:         bus,
+		blocks:      client,
+		bufferLimit: defaultBufferLimit,
+		maxReplay:   defaultMaxReplay,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Handler returns http handler of the server. Streams are served at
+//
+//	/ws  - websocket, each event is a binary frame framed by util/wsutil with FrameEvent id
+//	/sse - server sent events, id of each event is its resume token
+//
+// Both accept query parameters owner, dseq, provider and action (repeatable) filtering events,
+// and after with resume token of the last received event. Last-Event-ID header
+// sent by reconnecting SSE clients takes precedence over the after parameter.
+// Requests with resume token older than the server replays are rejected with 410 Gone.
+func (s *Server) Handler() http.Handler {
+	router := mux.NewRouter()
+
+	router.HandleFunc("/ws", s.serveWebsocket).Methods(http.MethodGet)
+	router.HandleFunc("/sse", s.serveSSE).Methods(http.MethodGet)
+
+	return router
+}
+
+func (s *Server) serveWebsocket(w http.ResponseWriter, r *http.Request) {
+	req, ok := s.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// clients are not expected to send anything, reading detects closed connection
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	lock := &sync.Mutex{}
+	evw := wsutil.NewWsWriterWrapper(conn, FrameEvent, lock)
+
+	err = s.stream(ctx, req, func(env events.Envelope) error {
+		data, err := json.Marshal(env)
+		if err != nil {
+			return err
+		}
+
+		_, err = evw.Write(data)
+		return err
+	})
+
+	if err != nil && ctx.Err() == nil {
+		errw := wsutil.NewWsWriterWrapper(conn, FrameError, lock)
+		_, _ = errw.Write([]byte(err.Error()))
+	}
+}
+
+func (s *Server) serveSSE(w http.ResponseWriter, r *http.Request) {
+	req, ok := s.parseRequest(w, r)
+	if !ok {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	err := s.stream(r.Context(), req, func(env events.Envelope) error {
+		data, err := json.Marshal(env)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "id: %s\ndata: %s\n\n", env.Position, data); err != nil {
+			return err
+		}
+
+		flusher.Flush()
+
+		return nil
+	})
+
+	if err != nil && r.Context().Err() == nil {
+		_, _ = fmt.Fprintf(w, "event: error\ndata: %s\n\n", err)
+		flusher.Flush()
+	}
+}
+
+// stream sends events matching the request until context is done or the bus is closed.
+// Events of a resumed stream are replayed from the resume token up to the latest block
+// before live events are sent
+func (s *Server) stream(ctx context.Context, req request, send func(events.Envelope) error) error {
+	// subscribe before replay so no events are missed in between
+	sub, err := s.bus.SubscribeWithOptions(
+		pubsub.WithFilter(req.filter.Accept),
+		pubsub.WithBufferLimit(s.bufferLimit, pubsub.OverflowClose),
+	)
+	if err != nil {
+		return err
+	}
+	defer sub.Close()
+
+	// live events up to this position have been replayed already
+	last := req.after
+
+	if req.resume {
+		if last, err = s.replay(ctx, req, send); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-sub.Done():
+			return sub.Err()
+		case ev := <-sub.Events():
+			env, ok := ev.(events.Envelope)
+			if !ok || (req.resume && !env.Position.After(last)) {
+				continue
+			}
+
+			if err := send(env); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// parseRequest parses request and checks its resume token can be replayed.
+// Responds with error and returns false otherwise
+func (s *Server) parseRequest(w http.ResponseWriter, r *http.Request) (request, bool) {
+	req, err := parseRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return req, false
+	}
+
+	if !req.resume {
+		return req, true
+	}
+
+	latest, err := s.blocks.BlockResults(r.Context(), nil)
+	if err == nil {
+		err = s.checkReplay(req, latest.Height)
+	}
+
+	switch {
+	case errors.Is(err, ErrResumeTooOld):
+		http.Error(w, err.Error(), http.StatusGone)
+		return req, false
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return req, false
+	}
+
+	return req, true
+}
+
+// checkReplay returns ErrResumeTooOld if replay of the request up to latest height
+// exceeds replay limit of the server
+func (s *Server) checkReplay(req request, latest int64) error {
+	if latest-req.after.Height >= s.maxReplay {
+		return fmt.Errorf("%w: height %d is more than %d blocks behind %d",
+			ErrResumeTooOld, req.after.Height, s.maxReplay, latest)
+	}
+
+	return nil
+}
+
+// replay sends events following the resume token up to the latest block.
+// Returns position of the last replayed event
+func (s *Server) replay(ctx context.Context, req request, send func(events.Envelope) error) (events.Position, error) {
+	last := req.after
+
+	latest, err := s.blocks.BlockResults(ctx, nil)
+	if err != nil {
+		return last, err
+	}
+
+	// chain may have advanced since the request has been checked
+	if err := s.checkReplay(req, latest.Height); err != nil {
+		return last, err
+	}
+
+	from := req.after.Height
+	if from < 1 {
+		from = 1
+	}
+
+	if from > latest.Height {
+		return last, nil
+	}
+
+	pub := publisherFunc(func(ev pubsub.Event) error {
+		env, ok := ev.(events.Envelope)
+		if !ok || !env.Position.After(last) || !req.filter.Accept(env) {
+			return nil
+		}
+
+		last = env.Position
+
+		return send(env)
+	})
+
+	err = events.Replay(ctx, s.blocks, from, latest.Height, pub, events.WithEnvelope())
+
+	return last, err
+}
+
+type publisherFunc func(pubsub.Event) error
+
+func (fn publisherFunc) Publish(ev pubsub.Event) error {
+	return fn(ev)
+}
+
+type request struct {
+	filter events.Filter
+	after  events.Position
+	resume bool
+}
+
+func parseRequest(r *http.Request) (request, error) {
+	query := r.URL.Query()
+
+	req := request{
+		filter: events.Filter{
+			Owner:    query.Get("owner"),
+			Provider: query.Get("provider"),
+			Actions:  query["action"],
+		},
+	}
+
+	for _, addr := range []string{req.filter.Owner, req.filter.Provider} {
+		if addr == "" {
+			continue
+		}
+
+		if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+			return req, fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+		}
+	}
+
+	if val := query.Get("dseq"); val != "" {
+		dseq, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return req, fmt.Errorf("%w: invalid dseq %q", ErrInvalidRequest, val)
+		}
+		req.filter.DSeq = dseq
+	}
+
+	// SSE clients reconnect to the same URL with token of the last received event in the header
+	token := query.Get("after")
+	if val := r.Header.Get(headerLastEventID); val != "" {
+		token = val
+	}
+
+	if token != "" {
+		after, err := events.ParsePosition(token)
+		if err != nil {
+			return req, fmt.Errorf("%w: %s", ErrInvalidRequest, err)
+		}
+
+		req.after = after
+		req.resume = true
+	}
+
+	return req, nil
+}
@@ -0,0 +1,184 @@
+package gateway_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/akash-network/node/events"
+	"github.com/akash-network/node/events/gateway"
+	"github.com/akash-network/node/pubsub"
+	"github.com/akash-network/node/testutil"
+	"github.com/akash-network/node/testutil/network"
+	"github.com/akash-network/node/x/provider/client/cli"
+)
+
+type envelope struct {
+	Position events.Position `json:"position"`
+	Event    struct {
+		Context struct {
+			Module string `json:"module"`
+			Action string `json:"action"`
+		} `json:"context"`
+		Owner string `json:"owner"`
+	} `json:"event"`
+}
+
+type GatewayTestSuite struct {
+	suite.Suite
+
+	cfg     network.Config
+	network *network.Network
+	bus     pubsub.Bus
+	server  *httptest.Server
+	cancel  context.CancelFunc
+}
+
+func (s *GatewayTestSuite) SetupSuite() {
+	s.T().Log("setting up gateway test suite")
+
+	cfg := testutil.DefaultConfig()
+	cfg.NumValidators = 1
+
+	s.cfg = cfg
+	s.network = network.New(s.T(), cfg)
+
+	_, err := s.network.WaitForHeight(1)
+	s.Require().NoError(err)
+
+	val := s.network.Validators[0]
+
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.bus = pubsub.NewBus()
+
+	go func() {
+		_ = events.Publish(ctx, val.RPCClient, "gateway-test", s.bus, events.WithEnvelope())
+	}()
+
+	s.server = httptest.NewServer(gateway.NewServer(s.bus, val.RPCClient).Handler())
+}
+
+func (s *GatewayTestSuite) TearDownSuite() {
+	s.T().Log("tearing down gateway test suite")
+
+	s.cancel()
+	s.bus.Close()
+	s.server.Close()
+	s.network.Cleanup()
+}
+
+func (s *GatewayTestSuite) TestStreamAndResume() {
+	val := s.network.Validators[0]
+
+	query := url.Values{}
+	query.Set("provider", val.Address.String())
+	query.Set("action", "provider-created")
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(s.server.URL, "http")+"/ws?"+query.Encode(), nil)
+	s.Require().NoError(err)
+
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	providerPath, err := filepath.Abs("../../x/provider/testdata/provider.yaml")
+	s.Require().NoError(err)
+
+	_, err = cli.TxCreateProviderExec(
+		val.ClientCtx,
+		val.Address,
+		providerPath,
+		fmt.Sprintf("--%s=true", flags.FlagSkipConfirmation),
+		fmt.Sprintf("--%s=%s", flags.FlagBroadcastMode, flags.BroadcastBlock),
+		fmt.Sprintf("--%s=%s", flags.FlagFees, sdk.NewCoins(sdk.NewCoin(s.cfg.BondDenom, sdk.NewInt(10))).String()),
+		fmt.Sprintf("--gas=%d", flags.DefaultGasLimit),
+	)
+	s.Require().NoError(err)
+
+	// live stream over websocket
+	s.Require().NoError(conn.SetReadDeadline(time.Now().Add(30 * time.Second)))
+
+	mtype, data, err := conn.ReadMessage()
+	s.Require().NoError(err)
+	s.Require().Equal(websocket.BinaryMessage, mtype)
+	s.Require().Equal(gateway.FrameEvent, data[0])
+
+	var live envelope
+	s.Require().NoError(json.Unmarshal(data[1:], &live))
+	s.Require().Equal("provider-created", live.Event.Context.Action)
+	s.Require().Equal(val.Address.String(), live.Event.Owner)
+
+	// resumed stream over SSE replays the event
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.server.URL+"/sse?"+query.Encode(), nil)
+	s.Require().NoError(err)
+	req.Header.Set("Last-Event-ID", events.Position{Height: live.Position.Height - 1}.String())
+
+	resp, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	s.Require().Equal("text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+
+	line, err := reader.ReadString('\n')
+	s.Require().NoError(err)
+	s.Require().Equal(fmt.Sprintf("id: %s\n", live.Position), line)
+
+	line, err = reader.ReadString('\n')
+	s.Require().NoError(err)
+	s.Require().True(strings.HasPrefix(line, "data: "))
+
+	var replayed envelope
+	s.Require().NoError(json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &replayed))
+	s.Require().Equal(live, replayed)
+}
+
+func (s *GatewayTestSuite) TestInvalidRequest() {
+	for _, query := range []string{"owner=invalid", "dseq=x", "after=1"} {
+		resp, err := http.Get(s.server.URL + "/sse?" + query) // nolint: gosec
+		s.Require().NoError(err)
+		_ = resp.Body.Close()
+
+		s.Require().Equal(http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func (s *GatewayTestSuite) TestResumeTooOld() {
+	server := httptest.NewServer(gateway.NewServer(s.bus, s.network.Validators[0].RPCClient, gateway.WithMaxReplay(1)).Handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/sse?after=" + events.Position{}.String()) // nolint: gosec
+	s.Require().NoError(err)
+	_ = resp.Body.Close()
+
+	s.Require().Equal(http.StatusGone, resp.StatusCode)
+}
+
+func TestGatewayTestSuite(t *testing.T) {
+	suite.Run(t, new(GatewayTestSuite))
+}
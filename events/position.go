@@ -0,0 +1,70 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/akash-network/node/pubsub"
+)
+
+// ErrInvalidPosition is the error returned when position token cannot be parsed
+var ErrInvalidPosition = errors.New("invalid position")
+
+// Position of an event in the chain.
+// Events of a block are ordered the way the block executes them: events of transactions
+// in order of transactions within the block, then end block events. Index of transaction
+// event is its index in the transaction result, with index of the transaction in the high
+// 32 bits. Index of end block event is its index in the end block results, with all bits
+// of the high 32 set, which no transaction index reaches.
+type Position struct {
+	Height int64  `json:"height"`
+	Index  uint64 `json:"index"`
+}
+
+// After returns true if position follows the other one
+func (p Position) After(other Position) bool {
+	return p.Height > other.Height || (p.Height == other.Height && p.Index > other.Index)
+}
+
+// String returns position token in form <height>-<index>
+func (p Position) String() string {
+	return fmt.Sprintf("%d-%d", p.Height, p.Index)
+}
+
+// ParsePosition parses position token returned by Position.String
+func ParsePosition(token string) (Position, error) {
+	parts := strings.Split(token, "-")
+	if len(parts) != 2 {
+		return Position{}, fmt.Errorf("%w: %q", ErrInvalidPosition, token)
+	}
+
+	height, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || height < 0 {
+		return Position{}, fmt.Errorf("%w: %q", ErrInvalidPosition, token)
+	}
+
+	index, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return Position{}, fmt.Errorf("%w: %q", ErrInvalidPosition, token)
+	}
+
+	return Position{Height: height, Index: index}, nil
+}
+
+// Envelope is an event with its position in the chain
+type Envelope struct {
+	Position Position     `json:"position"`
+	Event    pubsub.Event `json:"event"`
+
+	// subject of the event filters match, nil if it has not been extracted
+	subject *eventSubject
+}
+
+const endBlockEventIndex = uint64(math.MaxUint32) << 32
+
+func txEventIndex(tx uint32) uint64 {
+	return uint64(tx) << 32
+}
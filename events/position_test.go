@@ -0,0 +1,27 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPosition(t *testing.T) {
+	pos := Position{Height: 10, Index: txEventIndex(2) + 3}
+
+	parsed, err := ParsePosition(pos.String())
+	require.NoError(t, err)
+	assert.Equal(t, pos, parsed)
+
+	assert.True(t, pos.After(Position{Height: 10, Index: 3}))
+	assert.True(t, pos.After(Position{Height: 9, Index: endBlockEventIndex}))
+	assert.True(t, Position{Height: 10, Index: endBlockEventIndex}.After(pos))
+	assert.False(t, pos.After(pos))
+	assert.False(t, pos.After(Position{Height: 11}))
+
+	for _, token := range []string{"", "10", "10-", "-1-2", "a-1", "10-1-2"} {
+		_, err := ParsePosition(token)
+		assert.ErrorIs(t, err, ErrInvalidPosition, token)
+	}
+}
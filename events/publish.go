@@ -2,8 +2,10 @@ package events
 
 import (
 	"context"
-
-	"golang.org/x/sync/errgroup"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -18,82 +20,489 @@ import (
 	"github.com/akash-network/akash-api/go/sdkutil"
 
 	"github.com/akash-network/node/pubsub"
+	certtypes "github.com/akash-network/node/x/cert/types"
+	escrowtypes "github.com/akash-network/node/x/escrow/types"
+)
+
+const (
+	queuesz = 100
+
+	// number of heights transactions are remembered for to deduplicate events
+	trackedHeights = 1000
+)
+
+var (
+	// ErrInvalidHeightRange is the error replay of invalid range of heights fails with
+	ErrInvalidHeightRange = errors.New("invalid height range")
+
+	errStreamClosed  = errors.New("events stream closed")
+	errStreamStalled = errors.New("events stream stalled")
 )
 
+// BlockResultsClient fetches results of committed blocks
+type BlockResultsClient interface {
+	BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error)
+}
+
+// Client is tendermint RPC client events are streamed and backfilled from
+type Client interface {
+	tmclient.EventsClient
+	BlockResultsClient
+}
+
+type publishOptions struct {
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	stallTimeout time.Duration
+	startHeight  int64
+	envelope     bool
+}
+
+// PublishOption configures Publish
+type PublishOption func(*publishOptions)
+
+// WithBackoff sets delays between reconnect attempts. Delay starts at min
+// and doubles on every failed attempt up to max
+func WithBackoff(min, max time.Duration) PublishOption {
+	return func(opts *publishOptions) {
+		opts.minBackoff = min
+		opts.maxBackoff = max
+	}
+}
+
+// WithStallTimeout sets time without new blocks after which stream is considered dead
+// and is re-subscribed
+func WithStallTimeout(timeout time.Duration) PublishOption {
+	return func(opts *publishOptions) {
+		opts.stallTimeout = timeout
+	}
+}
+
+// WithStartHeight publishes events starting from given height. Blocks produced
+// before the first streamed one are backfilled
+func WithStartHeight(height int64) PublishOption {
+	return func(opts *publishOptions) {
+		opts.startHeight = height
+	}
+}
+
+// WithEnvelope publishes events wrapped in Envelope with their position in the chain
+func WithEnvelope() PublishOption {
+	return func(opts *publishOptions) {
+		opts.envelope = true
+	}
+}
+
 // Publish events using tm buses to clients. Waits on context
 // shutdown signals to exit.
-func Publish(ctx context.Context, tmbus tmclient.EventsClient, name string, bus pubsub.Bus) (err error) {
+// Subscription is re-established with backoff whenever stream closes or stalls.
+// Events of blocks missed in between are backfilled from block results, in order
+// of heights and without duplicates, before resuming live streaming.
+// Events of a block are published in order of their positions, see Position. Streamed block
+// is published once all its transactions have been streamed, or is backfilled if the next
+// block is streamed first.
+func Publish(ctx context.Context, client Client, name string, bus pubsub.Bus, opts ...PublishOption) error {
+	popts := publishOptions{
+		minBackoff:   time.Second,
+		maxBackoff:   time.Minute,
+		stallTimeout: time.Minute,
+	}
 
-	const (
-		queuesz = 100
-	)
-	var (
-		txname  = name + "-tx"
-		blkname = name + "-blk"
-	)
+	for _, opt := range opts {
+		opt(&popts)
+	}
 
-	txch, err := tmbus.Subscribe(ctx, txname, txQuery().String(), queuesz)
-	if err != nil {
-		return err
+	p := &publisher{
+		events:  client,
+		blocks:  client,
+		txname:  name + "-tx",
+		blkname: name + "-blk",
+		pub:     bus,
+		opts:    popts,
+		tracker: newHeightTracker(),
+		pending: make(map[int64][]abci.TxResult),
 	}
-	defer func() {
-		err = tmbus.UnsubscribeAll(ctx, txname)
-	}()
 
-	blkch, err := tmbus.Subscribe(ctx, blkname, blkQuery().String(), queuesz)
-	if err != nil {
-		return err
+	if popts.startHeight > 0 {
+		p.tracker.height = popts.startHeight - 1
 	}
-	defer func() {
-		err = tmbus.UnsubscribeAll(ctx, blkname)
-	}()
 
-	g, ctx := errgroup.WithContext(ctx)
+	return p.run(ctx)
+}
 
-	g.Go(func() error {
-		return publishEvents(ctx, txch, bus)
-	})
+// Replay publishes events of blocks within [from, to] heights in order of their positions.
+// Only WithEnvelope option applies to replay
+func Replay(ctx context.Context, client BlockResultsClient, from, to int64, pub pubsub.Publisher, opts ...PublishOption) error {
+	if from <= 0 || to < from {
+		return fmt.Errorf("%w: [%d, %d]", ErrInvalidHeightRange, from, to)
+	}
 
-	g.Go(func() error {
-		return publishEvents(ctx, blkch, bus)
-	})
+	p := &publisher{
+		blocks:  client,
+		pub:     pub,
+		tracker: newHeightTracker(),
+	}
+
+	for _, opt := range opts {
+		opt(&p.opts)
+	}
+
+	for height := from; height <= to; height++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := p.backfill(ctx, height); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type publisher struct {
+	events  tmclient.EventsClient
+	blocks  BlockResultsClient
+	txname  string
+	blkname string
+	pub     pubsub.Publisher
+	opts    publishOptions
+	tracker *heightTracker
 
-	return g.Wait()
+	// transactions streamed but not published yet
+	pending map[int64][]abci.TxResult
+
+	// streamed block which transactions have not been streamed all yet
+	held *heldBlock
+}
+
+// heldBlock is streamed block header waiting for transactions of the block
+type heldBlock struct {
+	height int64
+	numTxs int64
+	events []abci.Event
 }
 
-func publishEvents(ctx context.Context, ch <-chan ctypes.ResultEvent, bus pubsub.Bus) error {
-	var err error
+func (p *publisher) run(ctx context.Context) error {
+	backoff := p.opts.minBackoff
 
-loop:
 	for {
+		progressed, err := p.stream(ctx)
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if errors.Is(err, pubsub.ErrNotRunning) {
+			return err
+		}
+
+		if progressed {
+			backoff = p.opts.minBackoff
+		}
+
 		select {
 		case <-ctx.Done():
-			break loop
-		case ed := <-ch:
-			switch evt := ed.Data.(type) {
-			case tmtmtypes.EventDataTx:
-				if !evt.Result.IsOK() {
-					continue
+			return nil
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > p.opts.maxBackoff {
+			backoff = p.opts.maxBackoff
+		}
+	}
+}
+
+// stream subscribes to transactions and block headers and publishes their events
+// until the stream fails. Returns true if any block has been processed
+func (p *publisher) stream(ctx context.Context) (bool, error) {
+	txch, err := p.subscribe(ctx, p.txname, txQuery().String())
+	if err != nil {
+		return false, err
+	}
+	defer p.unsubscribe(ctx, p.txname)
+
+	blkch, err := p.subscribe(ctx, p.blkname, blkQuery().String())
+	if err != nil {
+		return false, err
+	}
+	defer p.unsubscribe(ctx, p.blkname)
+
+	progressed := false
+	stallch := time.After(p.opts.stallTimeout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return progressed, nil
+		case <-stallch:
+			return progressed, errStreamStalled
+		case ed, ok := <-txch:
+			if !ok {
+				return progressed, errStreamClosed
+			}
+
+			if evt, valid := ed.Data.(tmtmtypes.EventDataTx); valid {
+				if err := p.streamTx(evt.TxResult); err != nil {
+					return progressed, err
+				}
+			}
+		case ed, ok := <-blkch:
+			if !ok {
+				return progressed, errStreamClosed
+			}
+
+			if evt, valid := ed.Data.(tmtmtypes.EventDataNewBlockHeader); valid {
+				if err := p.publishBlock(ctx, evt.Header.Height, evt.NumTxs, evt.ResultEndBlock.GetEvents()); err != nil {
+					return progressed, err
 				}
-				processEvents(bus, evt.Result.GetEvents())
-			case tmtmtypes.EventDataNewBlockHeader:
-				processEvents(bus, evt.ResultEndBlock.GetEvents())
+
+				progressed = true
+				stallch = time.After(p.opts.stallTimeout)
 			}
 		}
 	}
+}
+
+// subscribe is bounded by stall timeout as requests block while client is reconnecting
+func (p *publisher) subscribe(ctx context.Context, name, query string) (<-chan ctypes.ResultEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.opts.stallTimeout)
+	defer cancel()
+
+	return p.events.Subscribe(ctx, name, query, queuesz)
+}
+
+// unsubscribe is bounded by stall timeout as requests block while client is reconnecting
+func (p *publisher) unsubscribe(ctx context.Context, name string) {
+	ctx, cancel := context.WithTimeout(ctx, p.opts.stallTimeout)
+	defer cancel()
+
+	_ = p.events.UnsubscribeAll(ctx, name)
+}
+
+// streamTx holds transaction of a block not processed yet, and publishes
+// the held block once all its transactions have been streamed
+func (p *publisher) streamTx(tx abci.TxResult) error {
+	if tx.Height <= p.tracker.height {
+		return p.publishTx(tx.Height, tx.Index, &tx.Result)
+	}
+
+	p.pending[tx.Height] = append(p.pending[tx.Height], tx)
+
+	if p.held != nil && p.held.height == tx.Height {
+		return p.publishHeld()
+	}
+
+	return nil
+}
+
+// publishBlock backfills blocks missed since last processed one, including the held one
+// which transactions have not all been streamed, and holds the block until its
+// transactions are streamed
+func (p *publisher) publishBlock(ctx context.Context, height int64, numTxs int64, events []abci.Event) error {
+	if height <= p.tracker.height {
+		return nil
+	}
+
+	from := height
+	if p.tracker.height > 0 || p.opts.startHeight > 0 {
+		from = p.tracker.height + 1
+	}
+
+	if p.held != nil && p.held.height < from {
+		from = p.held.height
+	}
+
+	p.held = nil
+
+	for missed := from; missed < height; missed++ {
+		if err := p.backfill(ctx, missed); err != nil {
+			return err
+		}
+	}
+
+	p.held = &heldBlock{
+		height: height,
+		numTxs: numTxs,
+		events: events,
+	}
 
-	return err
+	return p.publishHeld()
 }
 
-func processEvents(bus pubsub.Bus, events []abci.Event) {
-	for _, ev := range events {
-		if mev, ok := processEvent(ev); ok {
-			if err := bus.Publish(mev); err != nil {
-				bus.Close()
-				return
+// publishHeld publishes transactions of the held block followed by its end block events
+// once all transactions of the block have been streamed
+func (p *publisher) publishHeld() error {
+	streamed := make(map[uint32]struct{})
+	for _, tx := range p.pending[p.held.height] {
+		streamed[tx.Index] = struct{}{}
+	}
+
+	if int64(len(streamed)) < p.held.numTxs {
+		return nil
+	}
+
+	held := p.held
+	p.held = nil
+
+	if err := p.publishPending(held.height); err != nil {
+		return err
+	}
+
+	if err := p.publishEvents(held.height, endBlockEventIndex, held.events); err != nil {
+		return err
+	}
+
+	p.tracker.markBlock(held.height)
+
+	return nil
+}
+
+// publishPending publishes held transactions of blocks up to given height.
+// Those of backfilled blocks have been published already and are skipped
+func (p *publisher) publishPending(height int64) error {
+	heights := make([]int64, 0, len(p.pending))
+	for pheight := range p.pending {
+		if pheight <= height {
+			heights = append(heights, pheight)
+		}
+	}
+
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+
+	for _, pheight := range heights {
+		txs := p.pending[pheight]
+		delete(p.pending, pheight)
+
+		sort.Slice(txs, func(i, j int) bool {
+			return txs[i].Index < txs[j].Index
+		})
+
+		for _, tx := range txs {
+			if err := p.publishTx(tx.Height, tx.Index, &tx.Result); err != nil {
+				return err
 			}
+		}
+	}
+
+	return nil
+}
+
+// backfill publishes events of transactions not seen yet followed by end block events
+// of the block at given height
+func (p *publisher) backfill(ctx context.Context, height int64) error {
+	res, err := p.blocks.BlockResults(ctx, &height)
+	if err != nil {
+		return err
+	}
+
+	for idx, tx := range res.TxsResults {
+		if err := p.publishTx(height, uint32(idx), tx); err != nil { // nolint: gosec
+			return err
+		}
+	}
+
+	if err := p.publishEvents(height, endBlockEventIndex, res.EndBlockEvents); err != nil {
+		return err
+	}
+
+	p.tracker.markBlock(height)
+
+	return nil
+}
+
+func (p *publisher) publishTx(height int64, index uint32, res *abci.ResponseDeliverTx) error {
+	if height < p.opts.startHeight || p.tracker.txSeen(height, index) {
+		return nil
+	}
+
+	p.tracker.markTx(height, index)
+
+	if !res.IsOK() {
+		return nil
+	}
+
+	return p.publishEvents(height, txEventIndex(index), res.GetEvents())
+}
+
+// publishEvents publishes events found in the list. Index of event position
+// is its index in the list added to base
+func (p *publisher) publishEvents(height int64, base uint64, events []abci.Event) error {
+	for idx, ev := range events {
+		sev, err := sdkutil.ParseEvent(sdk.StringifyEvent(ev))
+		if err != nil {
+			continue
+		}
+
+		mev, ok := moduleEvent(sev)
+		if !ok {
 			continue
 		}
+
+		var pev pubsub.Event = mev
+		if p.opts.envelope {
+			// subject is extracted once here rather than by filter of every subscriber
+			subj := newEventSubject(sev)
+
+			pev = Envelope{
+				Position: Position{Height: height, Index: base + uint64(idx)},
+				Event:    mev,
+				subject:  &subj,
+			}
+		}
+
+		if err := p.pub.Publish(pev); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// heightTracker tracks last processed block and transactions of recent blocks
+// to deduplicate events delivered both by live stream and backfill
+type heightTracker struct {
+	height int64
+	txs    map[int64]map[uint32]struct{}
+}
+
+func newHeightTracker() *heightTracker {
+	return &heightTracker{
+		txs: make(map[int64]map[uint32]struct{}),
+	}
+}
+
+// txSeen returns true if transaction has been processed.
+// Transactions of blocks too old to be tracked are considered processed
+func (t *heightTracker) txSeen(height int64, index uint32) bool {
+	if height <= t.height-trackedHeights {
+		return true
+	}
+
+	_, seen := t.txs[height][index]
+	return seen
+}
+
+func (t *heightTracker) markTx(height int64, index uint32) {
+	txs, ok := t.txs[height]
+	if !ok {
+		txs = make(map[uint32]struct{})
+		t.txs[height] = txs
+	}
+
+	txs[index] = struct{}{}
+}
+
+func (t *heightTracker) markBlock(height int64) {
+	t.height = height
+
+	for tracked := range t.txs {
+		if tracked <= height-trackedHeights {
+			delete(t.txs, tracked)
+		}
 	}
 }
 
@@ -103,6 +512,11 @@ func processEvent(bev abci.Event) (interface{}, bool) {
 		return nil, false
 	}
 
+	return moduleEvent(ev)
+}
+
+// moduleEvent returns module event of akash modules parsed from the event
+func moduleEvent(ev sdkutil.Event) (interface{}, bool) {
 	if mev, err := dtypes.ParseEvent(ev); err == nil {
 		return mev, true
 	}
@@ -119,5 +533,13 @@ func processEvent(bev abci.Event) (interface{}, bool) {
 		return mev, true
 	}
 
+	if mev, err := escrowtypes.ParseEvent(ev); err == nil {
+		return mev, true
+	}
+
+	if mev, err := certtypes.ParseEvent(ev); err == nil {
+		return mev, true
+	}
+
 	return nil, false
 }
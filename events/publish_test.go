@@ -1,17 +1,28 @@
 package events
 
 import (
+	"context"
+	"sync"
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 
+	cv1beta3 "github.com/akash-network/akash-api/go/node/cert/v1beta3"
 	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
 	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
 	ptypes "github.com/akash-network/akash-api/go/node/provider/v1beta3"
 	"github.com/akash-network/akash-api/go/sdkutil"
 
+	"github.com/akash-network/node/pubsub"
 	"github.com/akash-network/node/testutil"
+	certtypes "github.com/akash-network/node/x/cert/types"
+	escrowtypes "github.com/akash-network/node/x/escrow/types"
 )
 
 func Test_processEvent(t *testing.T) {
@@ -34,6 +45,21 @@ func Test_processEvent(t *testing.T) {
 		ptypes.NewEventProviderCreated(testutil.AccAddress(t)),
 		ptypes.NewEventProviderUpdated(testutil.AccAddress(t)),
 		ptypes.NewEventProviderDeleted(testutil.AccAddress(t)),
+
+		// x/escrow events
+		escrowtypes.NewEventAccountClosed(dtypes.EscrowAccountForDeployment(testutil.DeploymentID(t))),
+		escrowtypes.NewEventAccountOverdrawn(dtypes.EscrowAccountForDeployment(testutil.DeploymentID(t))),
+		escrowtypes.NewEventPaymentWithdrawn(
+			dtypes.EscrowAccountForDeployment(testutil.DeploymentID(t)),
+			mtypes.EscrowPaymentForLease(testutil.LeaseID(t)),
+			testutil.AccAddress(t),
+			testutil.AkashCoin(t, 100),
+		),
+
+		// x/cert events
+		certtypes.NewEventCertificateCreated(testutil.CertID(t), cv1beta3.CertificateValid),
+		certtypes.NewEventCertificateRevoked(testutil.CertID(t), cv1beta3.CertificateRevoked),
+		certtypes.NewEventCertificateExpired(testutil.CertID(t), cv1beta3.CertificateRevoked),
 	}
 
 	for _, test := range tests {
@@ -48,3 +74,335 @@ func Test_processEvent(t *testing.T) {
 		assert.Equal(t, test, ev, test)
 	}
 }
+
+func TestPublishBackfill(t *testing.T) {
+	evs := make([]sdkutil.ModuleEvent, 0, 6)
+	for i := 0; i < cap(evs); i++ {
+		evs = append(evs, mtypes.NewEventOrderCreated(testutil.OrderID(t)))
+	}
+
+	client := newTestClient()
+	client.results[2] = &ctypes.ResultBlockResults{
+		Height: 2,
+		TxsResults: []*abci.ResponseDeliverTx{
+			{Events: abciEvents(evs[1])},
+			{Code: 1, Events: abciEvents(mtypes.NewEventOrderCreated(testutil.OrderID(t)))},
+		},
+		EndBlockEvents: abciEvents(evs[2]),
+	}
+	client.results[3] = &ctypes.ResultBlockResults{
+		Height: 3,
+		TxsResults: []*abci.ResponseDeliverTx{
+			{Events: abciEvents(evs[3])},
+			{Events: abciEvents(evs[4])},
+		},
+	}
+
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	sub, err := bus.Subscribe()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Publish(ctx, client, "test", bus,
+			WithBackoff(time.Millisecond, 10*time.Millisecond),
+			WithStallTimeout(time.Minute))
+	}()
+
+	stream := client.nextStream(t)
+	stream.block(1, 0, evs[0])
+	assertEvents(t, sub, evs[0])
+
+	// node restarts while heights 2 and 3 are produced
+	stream.close()
+
+	stream = client.nextStream(t)
+
+	// live event of height 3 delivered before the block is held and not published again by backfill
+	stream.tx(3, 1, evs[4])
+	assertEvents(t, sub)
+
+	stream.block(4, 0, evs[5])
+	assertEvents(t, sub, evs[1], evs[2], evs[3], evs[4], evs[5])
+
+	stream.block(4, 0, evs[5])
+	assertEvents(t, sub)
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		require.Fail(t, "time out")
+	}
+}
+
+func TestPublishStalled(t *testing.T) {
+	ev := mtypes.NewEventOrderCreated(testutil.OrderID(t))
+
+	client := newTestClient()
+
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	sub, err := bus.Subscribe()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = Publish(ctx, client, "test", bus,
+			WithBackoff(time.Millisecond, 10*time.Millisecond),
+			WithStallTimeout(50*time.Millisecond))
+	}()
+
+	// stream without blocks is re-subscribed
+	client.nextStream(t)
+
+	stream := client.nextStream(t)
+	stream.block(1, 0, ev)
+	assertEvents(t, sub, ev)
+}
+
+func TestPublishStartHeight(t *testing.T) {
+	evs := make([]sdkutil.ModuleEvent, 0, 2)
+	for i := 0; i < cap(evs); i++ {
+		evs = append(evs, mtypes.NewEventOrderCreated(testutil.OrderID(t)))
+	}
+
+	client := newTestClient()
+	client.results[5] = &ctypes.ResultBlockResults{
+		Height:         5,
+		EndBlockEvents: abciEvents(evs[0]),
+	}
+
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	sub, err := bus.Subscribe()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = Publish(ctx, client, "test", bus, WithStartHeight(5))
+	}()
+
+	stream := client.nextStream(t)
+	stream.block(6, 0, evs[1])
+	assertEvents(t, sub, evs[0], evs[1])
+}
+
+func TestPublishEnvelope(t *testing.T) {
+	evs := make([]sdkutil.ModuleEvent, 0, 5)
+	for i := 0; i < cap(evs); i++ {
+		evs = append(evs, mtypes.NewEventOrderCreated(testutil.OrderID(t)))
+	}
+
+	client := newTestClient()
+	client.results[3] = &ctypes.ResultBlockResults{
+		Height: 3,
+		TxsResults: []*abci.ResponseDeliverTx{
+			{Events: abciEvents(evs[2])},
+		},
+		EndBlockEvents: abciEvents(evs[3]),
+	}
+
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	sub, err := bus.Subscribe()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = Publish(ctx, client, "test", bus, WithEnvelope())
+	}()
+
+	stream := client.nextStream(t)
+
+	// block is held until its transaction is streamed, which is published before end block events
+	stream.block(2, 1, evs[1])
+	stream.tx(2, 0, evs[0])
+
+	// block which transaction is not streamed before the next block is backfilled
+	stream.block(3, 1, evs[3])
+	stream.block(4, 0, evs[4])
+
+	for _, expected := range []Envelope{
+		{Position: Position{Height: 2, Index: 0}, Event: evs[0]},
+		{Position: Position{Height: 2, Index: endBlockEventIndex}, Event: evs[1]},
+		{Position: Position{Height: 3, Index: 0}, Event: evs[2]},
+		{Position: Position{Height: 3, Index: endBlockEventIndex}, Event: evs[3]},
+		{Position: Position{Height: 4, Index: endBlockEventIndex}, Event: evs[4]},
+	} {
+		select {
+		case ev := <-sub.Events():
+			require.IsType(t, Envelope{}, ev)
+			assert.Equal(t, expected.Position, ev.(Envelope).Position)
+			assert.Equal(t, expected.Event, ev.(Envelope).Event)
+		case <-time.After(time.Second):
+			require.Fail(t, "time out")
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	evs := make([]sdkutil.ModuleEvent, 0, 3)
+	for i := 0; i < cap(evs); i++ {
+		evs = append(evs, mtypes.NewEventOrderCreated(testutil.OrderID(t)))
+	}
+
+	client := newTestClient()
+	for height := int64(1); height <= 3; height++ {
+		client.results[height] = &ctypes.ResultBlockResults{
+			Height:         height,
+			EndBlockEvents: abciEvents(evs[height-1]),
+		}
+	}
+
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	sub, err := bus.Subscribe()
+	require.NoError(t, err)
+
+	require.NoError(t, Replay(context.Background(), client, 2, 3, bus))
+	assertEvents(t, sub, evs[1], evs[2])
+
+	err = Replay(context.Background(), client, 3, 2, bus)
+	assert.ErrorIs(t, err, ErrInvalidHeightRange)
+}
+
+func abciEvents(evs ...sdkutil.ModuleEvent) []abci.Event {
+	sdkevs := make(sdk.Events, 0, len(evs))
+	for _, ev := range evs {
+		sdkevs = append(sdkevs, ev.ToSDKEvent())
+	}
+
+	return sdkevs.ToABCIEvents()
+}
+
+func assertEvents(t *testing.T, sub pubsub.Subscriber, expected ...sdkutil.ModuleEvent) {
+	t.Helper()
+
+	for _, eev := range expected {
+		select {
+		case ev := <-sub.Events():
+			assert.Equal(t, eev, ev)
+		case <-time.After(time.Second):
+			require.Fail(t, "time out")
+		}
+	}
+
+	select {
+	case ev := <-sub.Events():
+		require.Fail(t, "spurious event", "%v", ev)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+type testStream struct {
+	txch  chan ctypes.ResultEvent
+	blkch chan ctypes.ResultEvent
+}
+
+func (s testStream) tx(height int64, index uint32, ev sdkutil.ModuleEvent) {
+	s.txch <- ctypes.ResultEvent{
+		Data: tmtypes.EventDataTx{
+			TxResult: abci.TxResult{
+				Height: height,
+				Index:  index,
+				Result: abci.ResponseDeliverTx{Events: abciEvents(ev)},
+			},
+		},
+	}
+}
+
+func (s testStream) block(height int64, numTxs int64, ev sdkutil.ModuleEvent) {
+	s.blkch <- ctypes.ResultEvent{
+		Data: tmtypes.EventDataNewBlockHeader{
+			Header:         tmtypes.Header{Height: height},
+			NumTxs:         numTxs,
+			ResultEndBlock: abci.ResponseEndBlock{Events: abciEvents(ev)},
+		},
+	}
+}
+
+func (s testStream) close() {
+	close(s.txch)
+	close(s.blkch)
+}
+
+type testClient struct {
+	mtx     sync.Mutex
+	current testStream
+	streams chan testStream
+	results map[int64]*ctypes.ResultBlockResults
+}
+
+func newTestClient() *testClient {
+	return &testClient{
+		streams: make(chan testStream, 1),
+		results: make(map[int64]*ctypes.ResultBlockResults),
+	}
+}
+
+func (c *testClient) nextStream(t *testing.T) testStream {
+	t.Helper()
+
+	select {
+	case stream := <-c.streams:
+		return stream
+	case <-time.After(time.Second):
+		require.Fail(t, "time out subscribing")
+	}
+
+	return testStream{}
+}
+
+func (c *testClient) Subscribe(ctx context.Context, _, query string, _ ...int) (<-chan ctypes.ResultEvent, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	ch := make(chan ctypes.ResultEvent)
+
+	if query == txQuery().String() {
+		c.current = testStream{txch: ch}
+		return ch, nil
+	}
+
+	c.current.blkch = ch
+
+	select {
+	case c.streams <- c.current:
+		return ch, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *testClient) Unsubscribe(context.Context, string, string) error {
+	return nil
+}
+
+func (c *testClient) UnsubscribeAll(context.Context, string) error {
+	return nil
+}
+
+func (c *testClient) BlockResults(_ context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return c.results[*height], nil
+}
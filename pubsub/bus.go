@@ -2,12 +2,22 @@ package pubsub
 
 import (
 	"errors"
+	"fmt"
+	"sync/atomic"
 
 	"github.com/boz/go-lifecycle"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-// ErrNotRunning is the error with message "not running"
-var ErrNotRunning = errors.New("not running")
+var (
+	// ErrNotRunning is the error with message "not running"
+	ErrNotRunning = errors.New("not running")
+	// ErrBufferOverflow is the error subscriber with OverflowClose policy is closed with
+	ErrBufferOverflow = errors.New("subscriber buffer overflow")
+)
+
+// busSeq is sequence number of the last bus created without name
+var busSeq atomic.Uint64
 
 // Event interface
 type Event interface{}
@@ -16,14 +26,34 @@ type Publisher interface {
 	Publish(Event) error
 }
 
+// Filter reports whether subscriber is interested in the event.
+// It is called on the publishing goroutine, so it must be fast and must not block.
+type Filter func(Event) bool
+
+// OverflowPolicy defines what subscriber does with a new event when its buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowDropOldest drops the oldest buffered event to make room for the new one
+	OverflowDropOldest OverflowPolicy = iota
+	// OverflowDropNewest drops the new event
+	OverflowDropNewest
+	// OverflowClose closes subscriber with ErrBufferOverflow
+	OverflowClose
+	// OverflowBlock blocks publisher until subscriber consumes buffered events
+	OverflowBlock
+)
+
 // Bus is an async event bus that allows subscriptions to behave as a bus themselves.
 // When an event is published, it is sent to all subscribers asynchronously - a subscriber
-// cannot block other subscribers.
+// cannot block other subscribers, unless it is subscribed with OverflowBlock policy.
 //
 // NOTE: this should probably be in util/event or something (not in provider/event)
 type Bus interface {
 	Publisher
 	Subscribe() (Subscriber, error)
+	SubscribeWithFilter(Filter) (Subscriber, error)
+	SubscribeWithOptions(...SubscriberOption) (Subscriber, error)
 	Close()
 	Done() <-chan struct{}
 }
@@ -31,36 +61,120 @@ type Bus interface {
 // Subscriber emits events it sees on the channel returned by Events().
 // A Clone() of a subscriber will emit all events that have not been emitted
 // from the cloned subscriber.  This is important so that events are not missed
-// when adding subscribers for sub-components (see `provider/bidengine/{service,order}.go`)
+// when adding subscribers for sub-components (see `provider/bidengine/{service,order}.go`).
+// A Clone() of a filtered subscriber emits only events matching the same filter,
+// and has the same buffer limit and overflow policy.
 type Subscriber interface {
 	Events() <-chan Event
 	Clone() (Subscriber, error)
 	Close()
 	Done() <-chan struct{}
+	// Dropped returns number of events subscriber missed due to buffer overflow
+	Dropped() uint64
+	// Err returns error subscriber was closed with, nil if it is running or closed by Close()
+	Err() error
+}
+
+type bufferOptions struct {
+	limit  int
+	policy OverflowPolicy
+}
+
+type busOptions struct {
+	name   string
+	buffer bufferOptions
+}
+
+// BusOption configures bus
+type BusOption func(*busOptions)
+
+// WithName sets name of the bus used as label of its metrics.
+// Buses without name are labeled with sequence number of their creation
+func WithName(name string) BusOption {
+	return func(opts *busOptions) {
+		opts.name = name
+	}
+}
+
+// WithDefaultBufferLimit sets buffer limit and overflow policy of subscribers
+// that are not subscribed with WithBufferLimit
+func WithDefaultBufferLimit(limit int, policy OverflowPolicy) BusOption {
+	return func(opts *busOptions) {
+		opts.buffer = bufferOptions{limit: limit, policy: policy}
+	}
+}
+
+type subscriberOptions struct {
+	filter Filter
+	buffer *bufferOptions
+}
+
+// SubscriberOption configures subscriber
+type SubscriberOption func(*subscriberOptions)
+
+// WithFilter sets filter of events published to subscriber
+func WithFilter(filter Filter) SubscriberOption {
+	return func(opts *subscriberOptions) {
+		opts.filter = filter
+	}
+}
+
+// WithBufferLimit sets maximum number of events buffered by subscriber
+// and policy applied when it is reached. Zero limit is unbounded
+func WithBufferLimit(limit int, policy OverflowPolicy) SubscriberOption {
+	return func(opts *subscriberOptions) {
+		opts.buffer = &bufferOptions{limit: limit, policy: policy}
+	}
 }
 
 type bus struct {
 	subscriptions map[*bus]bool
 
-	evbuf []Event
+	// filter of events published to this subscriber, nil accepts all events
+	filter Filter
+
+	// buffer limit of this subscriber and default of subscriptions to it
+	buffer bufferOptions
+
+	evbuf   []Event
+	dropped atomic.Uint64
 
 	eventch  chan Event
 	parentch chan *bus
 
 	pubch   chan Event
-	subch   chan chan<- Subscriber
+	subch   chan subscribeRequest
 	unsubch chan *bus
 
+	metrics busMetrics
+
 	lc lifecycle.Lifecycle
 }
 
+type subscribeRequest struct {
+	opts subscriberOptions
+	ch   chan<- Subscriber
+}
+
 // NewBus runs a new bus and returns bus details
-func NewBus() Bus {
+func NewBus(opts ...BusOption) Bus {
+	bopts := &busOptions{}
+
+	for _, opt := range opts {
+		opt(bopts)
+	}
+
+	if bopts.name == "" {
+		bopts.name = fmt.Sprintf("bus-%d", busSeq.Add(1))
+	}
+
 	bus := &bus{
 		subscriptions: make(map[*bus]bool),
+		buffer:        bopts.buffer,
 		pubch:         make(chan Event),
-		subch:         make(chan chan<- Subscriber),
+		subch:         make(chan subscribeRequest),
 		unsubch:       make(chan *bus),
+		metrics:       newBusMetrics(bopts.name),
 		lc:            lifecycle.New(),
 	}
 
@@ -79,10 +193,27 @@ func (b *bus) Publish(ev Event) error {
 }
 
 func (b *bus) Subscribe() (Subscriber, error) {
+	return b.SubscribeWithOptions()
+}
+
+// SubscribeWithFilter returns subscriber which receives only events accepted by the filter.
+// Events rejected by the filter are neither buffered nor forwarded to the subscriber.
+func (b *bus) SubscribeWithFilter(filter Filter) (Subscriber, error) {
+	return b.SubscribeWithOptions(WithFilter(filter))
+}
+
+// SubscribeWithOptions returns subscriber configured with given options
+func (b *bus) SubscribeWithOptions(opts ...SubscriberOption) (Subscriber, error) {
+	sopts := subscriberOptions{}
+
+	for _, opt := range opts {
+		opt(&sopts)
+	}
+
 	ch := make(chan Subscriber, 1)
 
 	select {
-	case b.subch <- ch:
+	case b.subch <- subscribeRequest{opts: sopts, ch: ch}:
 		return <-ch, nil
 	case <-b.lc.ShuttingDown():
 		return nil, ErrNotRunning
@@ -105,6 +236,19 @@ func (b *bus) Done() <-chan struct{} {
 	return b.lc.Done()
 }
 
+func (b *bus) Dropped() uint64 {
+	return b.dropped.Load()
+}
+
+func (b *bus) Err() error {
+	select {
+	case <-b.lc.Done():
+		return b.lc.Error()
+	default:
+		return nil
+	}
+}
+
 func (b *bus) run() {
 	defer b.lc.ShutdownCompleted()
 
@@ -125,6 +269,12 @@ loop:
 			outch = nil
 		}
 
+		pubch := b.pubch
+		if b.full() && b.buffer.policy == OverflowBlock {
+			// stop accepting events until buffered ones are emitted, which blocks publisher
+			pubch = nil
+		}
+
 		select {
 		case err := <-b.lc.ShutdownRequest():
 			b.lc.ShutdownInitiated(err)
@@ -133,29 +283,37 @@ loop:
 		case outch <- curev:
 			// Event was emitted. Shrink current event buffer.
 			b.evbuf = b.evbuf[1:]
+			b.metrics.depth.Dec()
 
-		case ev := <-b.pubch:
+		case ev := <-pubch:
 			// publish event
 
 			// Buffer event.
 			if b.eventch != nil {
-				b.evbuf = append(b.evbuf, ev)
+				if err := b.bufferEvent(ev); err != nil {
+					b.lc.ShutdownInitiated(err)
+					break loop
+				}
 			}
 
 			// Publish to children.
 			for sub := range b.subscriptions {
+				if !sub.accept(ev) {
+					continue
+				}
+
 				if err := sub.Publish(ev); err != nil && !errors.Is(err, ErrNotRunning) {
 					panic(err)
 				}
 			}
 
-		case ch := <-b.subch:
+		case req := <-b.subch:
 			// new subscription
 
-			sub := newSubscriber(b)
+			sub := newSubscriber(b, req.opts)
 			b.subscriptions[sub] = true
 
-			ch <- sub
+			req.ch <- sub
 
 		case sub := <-b.unsubch:
 			// subscription closed
@@ -163,6 +321,9 @@ loop:
 		}
 	}
 
+	b.metrics.depth.Sub(float64(len(b.evbuf)))
+	b.evbuf = nil
+
 	for sub := range b.subscriptions {
 		sub.lc.ShutdownAsync(nil)
 	}
@@ -177,26 +338,100 @@ loop:
 	}
 }
 
-func newSubscriber(parent *bus) *bus {
+func (b *bus) accept(ev Event) bool {
+	return b.filter == nil || b.filter(ev)
+}
+
+func (b *bus) full() bool {
+	return b.buffer.limit > 0 && len(b.evbuf) >= b.buffer.limit
+}
+
+// bufferEvent appends event to the buffer applying overflow policy if it is full.
+// Returns error if subscriber has to be closed
+func (b *bus) bufferEvent(ev Event) error {
+	if b.full() {
+		b.dropped.Add(1)
+		b.metrics.dropped.Inc()
+
+		switch b.buffer.policy {
+		case OverflowDropNewest:
+			return nil
+		case OverflowClose:
+			return ErrBufferOverflow
+		default:
+			b.evbuf = b.evbuf[1:]
+			b.metrics.depth.Dec()
+		}
+	}
+
+	b.evbuf = append(b.evbuf, ev)
+	b.metrics.depth.Inc()
+
+	return nil
+}
+
+func newSubscriber(parent *bus, opts subscriberOptions) *bus {
 	// Re-use bus struct, but populate output channel (eventch)
 	// to enable subscriber mode.
 
-	evbuf := make([]Event, len(parent.evbuf))
-	copy(evbuf, parent.evbuf)
-
 	sub := &bus{
 		eventch:  make(chan Event),
 		parentch: parent.unsubch,
-		evbuf:    evbuf,
+		filter:   opts.filter,
+		buffer:   parent.buffer,
 
 		subscriptions: make(map[*bus]bool),
 		pubch:         make(chan Event),
-		subch:         make(chan chan<- Subscriber),
+		subch:         make(chan subscribeRequest),
 		unsubch:       make(chan *bus),
+		metrics:       parent.metrics,
 		lc:            lifecycle.New(),
 	}
 
+	if opts.buffer != nil {
+		sub.buffer = *opts.buffer
+	}
+
+	// events parent has not emitted yet. parent of a clone already
+	// applied its own filter, so clone inherits it
+	var err error
+
+	sub.evbuf = make([]Event, 0, len(parent.evbuf))
+	for _, ev := range parent.evbuf {
+		if !sub.accept(ev) {
+			continue
+		}
+
+		// parent can not be blocked by its subscriber,
+		// buffer is drained before new events are accepted instead
+		if sub.buffer.policy == OverflowBlock {
+			sub.evbuf = append(sub.evbuf, ev)
+			sub.metrics.depth.Inc()
+			continue
+		}
+
+		if err = sub.bufferEvent(ev); err != nil {
+			break
+		}
+	}
+
 	go sub.run()
 
+	if err != nil {
+		sub.lc.ShutdownAsync(err)
+	}
+
 	return sub
 }
+
+type busMetrics struct {
+	depth   prometheus.Gauge
+	dropped prometheus.Counter
+}
+
+func newBusMetrics(name string) busMetrics {
+	return busMetrics{
+		depth:   bufferDepth.WithLabelValues(name),
+		dropped: droppedEvents.WithLabelValues(name),
+	}
+}
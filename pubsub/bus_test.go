@@ -1,6 +1,8 @@
 package pubsub_test
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/akash-network/node/pubsub"
@@ -142,8 +144,328 @@ func TestClone(t *testing.T) {
 
 }
 
+func TestSubscribeWithFilter(t *testing.T) {
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	ev1 := newEvent(ed25519.GenPrivKey().PubKey().Address())
+	ev2 := newEvent(ed25519.GenPrivKey().PubKey().Address())
+
+	sub1, err := bus.SubscribeWithFilter(func(ev pubsub.Event) bool {
+		return assert.ObjectsAreEqual(ev1, ev)
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, bus.Publish(ev2))
+	assert.NoError(t, bus.Publish(ev1))
+	assert.NoError(t, bus.Publish(ev2))
+
+	// allow event propagation
+	pubsub.SleepForThreadStart(t)
+
+	// clone inherits filter
+	sub2, err := sub1.Clone()
+	require.NoError(t, err)
+
+	assert.NoError(t, bus.Publish(ev2))
+
+	for _, sub := range []pubsub.Subscriber{sub1, sub2} {
+		select {
+		case ev := <-sub.Events():
+			assert.Equal(t, ev1, ev)
+		case <-pubsub.AfterThreadStart(t):
+			require.Fail(t, "time out")
+		}
+
+		select {
+		case ev := <-sub.Events():
+			require.Fail(t, "spurious event", "%v", ev)
+		case <-pubsub.AfterThreadStart(t):
+		}
+	}
+
+	sub1.Close()
+
+	select {
+	case <-sub2.Done():
+	case <-pubsub.AfterThreadStart(t):
+		require.Fail(t, "time out closing sub2")
+	}
+}
+
+func TestBufferOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   pubsub.OverflowPolicy
+		expected []pubsub.Event
+	}{
+		{name: "drop-oldest", policy: pubsub.OverflowDropOldest, expected: []pubsub.Event{3, 4}},
+		{name: "drop-newest", policy: pubsub.OverflowDropNewest, expected: []pubsub.Event{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := pubsub.NewBus()
+			defer bus.Close()
+
+			sub, err := bus.SubscribeWithOptions(pubsub.WithBufferLimit(2, tt.policy))
+			require.NoError(t, err)
+
+			for i := 1; i <= 4; i++ {
+				require.NoError(t, bus.Publish(i))
+			}
+
+			// allow event propagation
+			pubsub.SleepForThreadStart(t)
+
+			assertEvents(t, sub, tt.expected...)
+			assert.Equal(t, uint64(2), sub.Dropped())
+		})
+	}
+}
+
+func TestBufferOverflowClose(t *testing.T) {
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	sub1, err := bus.SubscribeWithOptions(pubsub.WithBufferLimit(1, pubsub.OverflowClose))
+	require.NoError(t, err)
+
+	sub2, err := bus.Subscribe()
+	require.NoError(t, err)
+
+	require.NoError(t, bus.Publish(1))
+	assert.NoError(t, sub1.Err())
+
+	require.NoError(t, bus.Publish(2))
+
+	select {
+	case <-sub1.Done():
+	case <-pubsub.AfterThreadStart(t):
+		require.Fail(t, "time out closing sub1")
+	}
+
+	assert.ErrorIs(t, sub1.Err(), pubsub.ErrBufferOverflow)
+	assert.Equal(t, uint64(1), sub1.Dropped())
+
+	// other subscribers are not affected
+	require.NoError(t, bus.Publish(3))
+	assertEvents(t, sub2, 1, 2, 3)
+
+	sub2.Close()
+
+	select {
+	case <-sub2.Done():
+	case <-pubsub.AfterThreadStart(t):
+		require.Fail(t, "time out closing sub2")
+	}
+
+	assert.NoError(t, sub2.Err())
+}
+
+func TestBufferOverflowBlock(t *testing.T) {
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	sub, err := bus.SubscribeWithOptions(pubsub.WithBufferLimit(1, pubsub.OverflowBlock))
+	require.NoError(t, err)
+
+	published := make(chan struct{})
+
+	go func() {
+		defer close(published)
+		for i := 1; i <= 3; i++ {
+			assert.NoError(t, bus.Publish(i))
+		}
+	}()
+
+	select {
+	case <-published:
+		require.Fail(t, "publisher has not been blocked")
+	case <-pubsub.AfterThreadStart(t):
+	}
+
+	assertEvents(t, sub, 1, 2, 3)
+
+	select {
+	case <-published:
+	case <-pubsub.AfterThreadStart(t):
+		require.Fail(t, "time out publishing")
+	}
+
+	assert.Equal(t, uint64(0), sub.Dropped())
+}
+
+func TestDefaultBufferLimit(t *testing.T) {
+	bus := pubsub.NewBus(pubsub.WithName("test"), pubsub.WithDefaultBufferLimit(1, pubsub.OverflowDropOldest))
+	defer bus.Close()
+
+	sub1, err := bus.Subscribe()
+	require.NoError(t, err)
+
+	// clone inherits buffer limit
+	sub2, err := sub1.Clone()
+	require.NoError(t, err)
+
+	sub3, err := bus.SubscribeWithOptions(pubsub.WithBufferLimit(0, pubsub.OverflowDropOldest))
+	require.NoError(t, err)
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, bus.Publish(i))
+	}
+
+	// allow event propagation
+	pubsub.SleepForThreadStart(t)
+
+	assertEvents(t, sub1, 3)
+	assertEvents(t, sub2, 3)
+	assertEvents(t, sub3, 1, 2, 3)
+
+	assert.Equal(t, uint64(2), sub1.Dropped())
+	assert.Equal(t, uint64(2), sub2.Dropped())
+	assert.Equal(t, uint64(0), sub3.Dropped())
+}
+
+func TestSubscribeBufferedOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   pubsub.OverflowPolicy
+		expected []pubsub.Event
+		dropped  uint64
+		err      error
+	}{
+		{name: "drop-oldest", policy: pubsub.OverflowDropOldest, expected: []pubsub.Event{2, 3}, dropped: 1},
+		{name: "drop-newest", policy: pubsub.OverflowDropNewest, expected: []pubsub.Event{1, 2}, dropped: 1},
+		{name: "block", policy: pubsub.OverflowBlock, expected: []pubsub.Event{1, 2, 3}},
+		{name: "close", policy: pubsub.OverflowClose, dropped: 1, err: pubsub.ErrBufferOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := pubsub.NewBus()
+			defer bus.Close()
+
+			parent, err := bus.Subscribe()
+			require.NoError(t, err)
+
+			for i := 1; i <= 3; i++ {
+				require.NoError(t, bus.Publish(i))
+			}
+
+			// allow event propagation
+			pubsub.SleepForThreadStart(t)
+
+			// events parent has not emitted are copied to subscriber applying its policy
+			sub, err := parent.(pubsub.Bus).SubscribeWithOptions(pubsub.WithBufferLimit(2, tt.policy))
+			require.NoError(t, err)
+
+			if tt.err != nil {
+				select {
+				case <-sub.Done():
+				case <-pubsub.AfterThreadStart(t):
+					require.Fail(t, "time out closing subscriber")
+				}
+
+				assert.ErrorIs(t, sub.Err(), tt.err)
+			}
+
+			assertEvents(t, sub, tt.expected...)
+			assert.Equal(t, tt.dropped, sub.Dropped())
+		})
+	}
+}
+
+// assertEvents asserts subscriber emits exactly expected events
+func assertEvents(t *testing.T, sub pubsub.Subscriber, expected ...pubsub.Event) {
+	t.Helper()
+
+	for i, pev := range expected {
+		select {
+		case ev := <-sub.Events():
+			assert.Equal(t, pev, ev, "event %v", i+1)
+		case <-pubsub.AfterThreadStart(t):
+			require.Fail(t, "time out", "event %v", i+1)
+		}
+	}
+
+	select {
+	case ev := <-sub.Events():
+		require.Fail(t, "spurious event", "%v", ev)
+	case <-pubsub.AfterThreadStart(t):
+	}
+}
+
 type testEvent []byte
 
 func newEvent(addr []byte) testEvent {
 	return testEvent(addr)
 }
+
+func BenchmarkBusFanOut(b *testing.B) {
+	for _, subs := range []int{1, 10, 50} {
+		b.Run(fmt.Sprintf("subscribers=%d/all", subs), func(b *testing.B) {
+			benchmarkBus(b, subs, false)
+		})
+		b.Run(fmt.Sprintf("subscribers=%d/filtered", subs), func(b *testing.B) {
+			benchmarkBus(b, subs, true)
+		})
+	}
+}
+
+type benchDone struct{}
+
+// benchmarkBus publishes events meant for a single subscriber each. Subscribers either
+// receive all events and filter them on their own, or subscribe with the filter
+func benchmarkBus(b *testing.B, subs int, filtered bool) {
+	bus := pubsub.NewBus()
+	defer bus.Close()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < subs; i++ {
+		match := func(ev pubsub.Event) bool {
+			switch val := ev.(type) {
+			case int:
+				return val == i
+			default:
+				return true
+			}
+		}
+
+		var sub pubsub.Subscriber
+		var err error
+
+		if filtered {
+			sub, err = bus.SubscribeWithFilter(match)
+		} else {
+			sub, err = bus.Subscribe()
+		}
+		require.NoError(b, err)
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for ev := range sub.Events() {
+				if _, done := ev.(benchDone); done {
+					return
+				}
+
+				// unfiltered subscribers have to drop events of others on their own
+				_ = match(ev)
+			}
+		}()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, bus.Publish(i%subs))
+	}
+
+	require.NoError(b, bus.Publish(benchDone{}))
+
+	wg.Wait()
+}
@@ -0,0 +1,18 @@
+package pubsub
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	bufferDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "akash_pubsub_buffer_depth",
+		Help: "Number of events buffered by subscribers of the bus",
+	}, []string{"bus"})
+
+	droppedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "akash_pubsub_dropped_events_total",
+		Help: "Number of events dropped by subscribers of the bus due to buffer overflow",
+	}, []string{"bus"})
+)
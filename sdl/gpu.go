@@ -2,22 +2,31 @@ package sdl
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/akash-network/akash-api/go/node/types/unit"
 	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
 )
 
 type gpuInterface string
 
-type v2GPUNvidia struct {
+const (
+	gpuInterfacePCIe gpuInterface = "pcie"
+	gpuInterfaceSXM  gpuInterface = "sxm"
+	gpuInterfaceOAM  gpuInterface = "oam"
+)
+
+type v2GPUModel struct {
 	Model     string          `yaml:"model"`
 	RAM       *memoryQuantity `yaml:"ram,omitempty"`
 	Interface *gpuInterface   `yaml:"interface,omitempty"`
 }
 
-func (sdl *v2GPUNvidia) String() string {
+func (sdl *v2GPUModel) String() string {
 	key := sdl.Model
 	if sdl.RAM != nil {
 		key = fmt.Sprintf("%s/ram/%s", key, sdl.RAM.StringWithSuffix("Gi"))
@@ -30,10 +39,59 @@ func (sdl *v2GPUNvidia) String() string {
 	return key
 }
 
-type v2GPUsNvidia []v2GPUNvidia
+type v2GPUModels []v2GPUModel
+
+// gpuVendorSpec describes GPU models, RAM and interfaces accepted for a particular vendor.
+// Nil model pattern and zero RAM granularity leave respective field unchecked.
+type gpuVendorSpec struct {
+	model      *regexp.Regexp
+	ramStep    uint64
+	interfaces []gpuInterface
+}
 
-type gpuVendor struct {
-	Nvidia v2GPUsNvidia `yaml:"nvidia,omitempty"`
+// gpuVendors is the registry of GPU vendors supported in SDL, keyed by name used
+// in both SDL and provider attributes (vendor/<name>/model/...)
+var gpuVendors = map[string]gpuVendorSpec{
+	// nvidia models and RAM are left unchecked to keep accepting existing SDLs
+	"nvidia": {
+		interfaces: []gpuInterface{gpuInterfacePCIe, gpuInterfaceSXM},
+	},
+	"amd": {
+		model:      regexp.MustCompile(`^[a-z0-9][a-z0-9\-]*$`),
+		ramStep:    unit.Gi,
+		interfaces: []gpuInterface{gpuInterfacePCIe, gpuInterfaceOAM},
+	},
+	"intel": {
+		model:      regexp.MustCompile(`^[a-z0-9][a-z0-9\-]*$`),
+		ramStep:    unit.Gi,
+		interfaces: []gpuInterface{gpuInterfacePCIe, gpuInterfaceOAM},
+	},
+}
+
+func (spec gpuVendorSpec) validate(vendor string, model v2GPUModel) error {
+	if spec.model != nil && !spec.model.MatchString(model.Model) {
+		return fmt.Errorf("sdl: invalid GPU model (%s) for vendor %s", model.Model, vendor)
+	}
+
+	if spec.ramStep > 0 && model.RAM != nil && (*model.RAM == 0 || uint64(*model.RAM)%spec.ramStep != 0) {
+		return fmt.Errorf("sdl: invalid GPU RAM for %s model %s. expected multiple of %dGi", vendor, model.Model, spec.ramStep/unit.Gi)
+	}
+
+	if model.Interface == nil {
+		return nil
+	}
+
+	expected := make([]string, 0, len(spec.interfaces))
+
+	for _, iface := range spec.interfaces {
+		if iface == *model.Interface {
+			return nil
+		}
+
+		expected = append(expected, string(iface))
+	}
+
+	return fmt.Errorf("sdl: invalid GPU interface %s for vendor %s. expected \"%s\"", *model.Interface, vendor, strings.Join(expected, "|"))
 }
 
 type v2GPUAttributes types.Attributes
@@ -73,12 +131,12 @@ func (sdl *v2ResourceGPU) UnmarshalYAML(node *yaml.Node) error {
 func (sdl *v2GPUAttributes) UnmarshalYAML(node *yaml.Node) error {
 	var res types.Attributes
 
-	var vendor *gpuVendor
+	var vendors map[string]v2GPUModels
 
 	for i := 0; i < len(node.Content); i += 2 {
 		switch node.Content[i].Value {
 		case "vendor":
-			if err := node.Content[i+1].Decode(&vendor); err != nil {
+			if err := node.Content[i+1].Decode(&vendors); err != nil {
 				return err
 			}
 		default:
@@ -86,24 +144,35 @@ func (sdl *v2GPUAttributes) UnmarshalYAML(node *yaml.Node) error {
 		}
 	}
 
-	if vendor == nil {
+	if len(vendors) == 0 {
 		return fmt.Errorf("sdl: invalid GPU attributes. at least one vendor must be set")
 	}
 
-	res = make(types.Attributes, 0, len(vendor.Nvidia))
+	for vendor, models := range vendors {
+		spec, valid := gpuVendors[vendor]
+		if !valid {
+			return fmt.Errorf("sdl: unsupported GPU vendor (%s)", vendor)
+		}
 
-	for _, model := range vendor.Nvidia {
-		res = append(res, types.Attribute{
-			Key:   fmt.Sprintf("vendor/nvidia/model/%s", model.String()),
-			Value: "true",
-		})
-	}
+		if len(models) == 0 {
+			res = append(res, types.Attribute{
+				Key:   fmt.Sprintf("vendor/%s/model/*", vendor),
+				Value: "true",
+			})
 
-	if len(res) == 0 {
-		res = append(res, types.Attribute{
-			Key:   "vendor/nvidia/model/*",
-			Value: "true",
-		})
+			continue
+		}
+
+		for _, model := range models {
+			if err := spec.validate(vendor, model); err != nil {
+				return err
+			}
+
+			res = append(res, types.Attribute{
+				Key:   fmt.Sprintf("vendor/%s/model/%s", vendor, model.String()),
+				Value: "true",
+			})
+		}
 	}
 
 	sort.Sort(res)
@@ -118,11 +187,12 @@ func (sdl *v2GPUAttributes) UnmarshalYAML(node *yaml.Node) error {
 }
 
 func (sdl *gpuInterface) UnmarshalYAML(node *yaml.Node) error {
-	switch node.Value {
-	case "pcie":
-	case "sxm":
+	switch gpuInterface(node.Value) {
+	case gpuInterfacePCIe:
+	case gpuInterfaceSXM:
+	case gpuInterfaceOAM:
 	default:
-		return fmt.Errorf("sdl: invalid GPU interface %s. expected \"pcie|sxm\"", node.Value)
+		return fmt.Errorf("sdl: invalid GPU interface %s. expected \"pcie|sxm|oam\"", node.Value)
 	}
 
 	*sdl = gpuInterface(node.Value)
@@ -1,6 +1,7 @@
 package sdl
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -188,3 +189,179 @@ attributes:
 	require.Equal(t, "vendor/nvidia/model/a6000", p.Attributes[1].Key)
 	require.Equal(t, "true", p.Attributes[1].Value)
 }
+
+func TestV2ResourceGPU_UnsupportedVendor(t *testing.T) {
+	var stream = `
+units: 1
+attributes:
+  vendor:
+    matrox:
+`
+	var p v2ResourceGPU
+
+	err := yaml.Unmarshal([]byte(stream), &p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported GPU vendor (matrox)")
+}
+
+func TestV2ResourceGPU_AMDWildcard(t *testing.T) {
+	var stream = `
+units: 1
+attributes:
+  vendor:
+    amd:
+`
+	var p v2ResourceGPU
+
+	err := yaml.Unmarshal([]byte(stream), &p)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(p.Attributes))
+	require.Equal(t, "vendor/amd/model/*", p.Attributes[0].Key)
+	require.Equal(t, "true", p.Attributes[0].Value)
+}
+
+func TestV2ResourceGPU_AMDModelWithOAM(t *testing.T) {
+	var stream = `
+units: 8
+attributes:
+  vendor:
+    amd:
+      - model: mi300x
+        ram: 192Gi
+        interface: oam
+`
+	var p v2ResourceGPU
+
+	err := yaml.Unmarshal([]byte(stream), &p)
+	require.NoError(t, err)
+	require.Equal(t, gpuQuantity(8), p.Units)
+	require.Equal(t, 1, len(p.Attributes))
+	require.Equal(t, "vendor/amd/model/mi300x/ram/192Gi/interface/oam", p.Attributes[0].Key)
+	require.Equal(t, "true", p.Attributes[0].Value)
+}
+
+func TestV2ResourceGPU_AMDInterfaceSXM(t *testing.T) {
+	var stream = `
+units: 1
+attributes:
+  vendor:
+    amd:
+      - model: mi250
+        interface: sxm
+`
+	var p v2ResourceGPU
+
+	err := yaml.Unmarshal([]byte(stream), &p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `expected "pcie|oam"`)
+}
+
+func TestV2ResourceGPU_NvidiaInterfaceOAM(t *testing.T) {
+	var stream = `
+units: 1
+attributes:
+  vendor:
+    nvidia:
+      - model: h100
+        interface: oam
+`
+	var p v2ResourceGPU
+
+	err := yaml.Unmarshal([]byte(stream), &p)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `expected "pcie|sxm"`)
+}
+
+func TestV2ResourceGPU_IntelModel(t *testing.T) {
+	var stream = `
+units: 1
+attributes:
+  vendor:
+    intel:
+      - model: max-1550
+        ram: 128Gi
+`
+	var p v2ResourceGPU
+
+	err := yaml.Unmarshal([]byte(stream), &p)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(p.Attributes))
+	require.Equal(t, "vendor/intel/model/max-1550/ram/128Gi", p.Attributes[0].Key)
+}
+
+func TestV2ResourceGPU_InvalidModel(t *testing.T) {
+	for _, vendor := range []string{"amd", "intel"} {
+		for _, model := range []string{"MI300X", "Max-1550", "-max1550", "max_1550"} {
+			stream := fmt.Sprintf(`
+units: 1
+attributes:
+  vendor:
+    %s:
+      - model: %s
+`, vendor, model)
+			var p v2ResourceGPU
+
+			err := yaml.Unmarshal([]byte(stream), &p)
+			require.Error(t, err, "%s/%s", vendor, model)
+			require.Contains(t, err.Error(), "invalid GPU model")
+		}
+	}
+}
+
+func TestV2ResourceGPU_InvalidRAM(t *testing.T) {
+	for _, vendor := range []string{"amd", "intel"} {
+		for _, ram := range []string{"1536Mi", "0Gi"} {
+			stream := fmt.Sprintf(`
+units: 1
+attributes:
+  vendor:
+    %s:
+      - model: gpu1
+        ram: %s
+`, vendor, ram)
+			var p v2ResourceGPU
+
+			err := yaml.Unmarshal([]byte(stream), &p)
+			require.Error(t, err, "%s/%s", vendor, ram)
+			require.Contains(t, err.Error(), "invalid GPU RAM")
+		}
+	}
+}
+
+func TestV2ResourceGPU_NvidiaModelUnchecked(t *testing.T) {
+	var stream = `
+units: 1
+attributes:
+  vendor:
+    nvidia:
+      - model: RTX_4090
+        ram: 1536Mi
+`
+	var p v2ResourceGPU
+
+	err := yaml.Unmarshal([]byte(stream), &p)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(p.Attributes))
+}
+
+func TestV2ResourceGPU_MultipleVendors(t *testing.T) {
+	var stream = `
+units: 1
+attributes:
+  vendor:
+    nvidia:
+      - model: a100
+    amd:
+      - model: mi250
+        interface: pcie
+    intel:
+`
+	var p v2ResourceGPU
+
+	err := yaml.Unmarshal([]byte(stream), &p)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(p.Attributes))
+	require.Equal(t, "vendor/amd/model/mi250/interface/pcie", p.Attributes[0].Key)
+	require.Equal(t, "vendor/intel/model/*", p.Attributes[1].Key)
+	require.Equal(t, "vendor/nvidia/model/a100", p.Attributes[2].Key)
+}
@@ -0,0 +1,255 @@
+package sdl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	sdlIncludeField = "include"
+)
+
+var (
+	errSDLIncludeCycle    = fmt.Errorf("%w: include cycle", errSDLInvalid)
+	errSDLIncludeConflict = fmt.Errorf("%w: include conflict", errSDLInvalid)
+	errSDLIncludeInvalid  = fmt.Errorf("%w: invalid include", errSDLInvalid)
+)
+
+// includeMergeDepth defines how deep top level sections are merged across included files.
+// Keys deeper than given depth defined in more than one file are reported as conflict.
+var includeMergeDepth = map[string]int{
+	"services":   1, // services.<name>
+	"profiles":   2, // profiles.<compute|placement>.<name>
+	"deployment": 2, // deployment.<service>.<placement>
+	"endpoints":  1, // endpoints.<name>
+}
+
+type includeResolver struct {
+	// stack of files currently being resolved, used to detect cycles
+	stack []string
+	// files already merged, each file is merged at most once
+	visited map[string]bool
+}
+
+// readFileWithIncludes reads SDL at given path and merges all files referenced by
+// include directive (transitively) into it. Include paths are relative to the including file.
+func readFileWithIncludes(path string) ([]byte, error) {
+	r := &includeResolver{
+		visited: make(map[string]bool),
+	}
+
+	node, err := r.resolve(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(node)
+}
+
+// resolve returns root mapping node of the file at given path with all its includes merged in.
+// Files that have already been merged return nil node.
+func (r *includeResolver) resolve(path string) (*yaml.Node, error) {
+	apath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, p := range r.stack {
+		if p == apath {
+			chain := append(append([]string{}, r.stack[idx:]...), apath)
+			return nil, fmt.Errorf("%w: %s", errSDLIncludeCycle, strings.Join(chain, " -> "))
+		}
+	}
+
+	if r.visited[apath] {
+		// already merged through another include
+		return nil, nil
+	}
+
+	r.visited[apath] = true
+
+	r.stack = append(r.stack, apath)
+	defer func() {
+		r.stack = r.stack[:len(r.stack)-1]
+	}()
+
+	buf, err := os.ReadFile(apath)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(buf, &doc); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: %s: expected mapping at document root", errSDLIncludeInvalid, path)
+	}
+
+	root := doc.Content[0]
+
+	includes, err := popIncludes(root)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	for _, include := range includes {
+		ipath := include
+		if !filepath.IsAbs(ipath) {
+			ipath = filepath.Join(filepath.Dir(apath), ipath)
+		}
+
+		inode, err := r.resolve(ipath)
+		if err != nil {
+			return nil, err
+		}
+
+		if inode == nil {
+			continue
+		}
+
+		if err := mergeIncludedDocument(root, inode); err != nil {
+			return nil, fmt.Errorf("%w: including %q from %s", err, include, path)
+		}
+	}
+
+	return root, nil
+}
+
+// popIncludes removes include directive from mapping node and returns list of included paths
+func popIncludes(node *yaml.Node) ([]string, error) {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value != sdlIncludeField {
+			continue
+		}
+
+		var includes []string
+		if err := node.Content[i+1].Decode(&includes); err != nil {
+			return nil, fmt.Errorf("%w: %s", errSDLIncludeInvalid, err.Error())
+		}
+
+		node.Content = append(node.Content[:i], node.Content[i+2:]...)
+
+		for _, include := range includes {
+			if include == "" {
+				return nil, fmt.Errorf("%w: empty path", errSDLIncludeInvalid)
+			}
+		}
+
+		return includes, nil
+	}
+
+	return nil, nil
+}
+
+func mergeIncludedDocument(dst, src *yaml.Node) error {
+	for i := 0; i < len(src.Content); i += 2 {
+		key := src.Content[i]
+		val := src.Content[i+1]
+
+		idx := mappingIndex(dst, key.Value)
+		if idx < 0 {
+			dst.Content = append(dst.Content, key, val)
+			continue
+		}
+
+		if key.Value == sdlVersionField {
+			if err := mergeVersion(dst.Content[idx+1], val); err != nil {
+				return err
+			}
+			continue
+		}
+
+		depth, mergeable := includeMergeDepth[key.Value]
+		if !mergeable {
+			return fmt.Errorf("%w: %q already defined", errSDLIncludeConflict, key.Value)
+		}
+
+		merged, err := mergeMapping(dst.Content[idx+1], val, depth, key.Value)
+		if err != nil {
+			return err
+		}
+
+		dst.Content[idx+1] = merged
+	}
+
+	return nil
+}
+
+func mergeVersion(dst, src *yaml.Node) error {
+	dver, err := semver.ParseTolerant(dst.Value)
+	if err != nil {
+		return err
+	}
+
+	sver, err := semver.ParseTolerant(src.Value)
+	if err != nil {
+		return err
+	}
+
+	if !dver.EQ(sver) {
+		return fmt.Errorf("%w: version %q does not match %q", errSDLIncludeConflict, src.Value, dst.Value)
+	}
+
+	return nil
+}
+
+// mergeMapping merges src mapping into dst down to given depth and returns resulting node.
+// Empty (null) sections are replaced by their counterpart.
+func mergeMapping(dst, src *yaml.Node, depth int, path string) (*yaml.Node, error) {
+	if isNullNode(src) {
+		return dst, nil
+	}
+
+	if isNullNode(dst) {
+		return src, nil
+	}
+
+	if depth == 0 {
+		return nil, fmt.Errorf("%w: %q already defined", errSDLIncludeConflict, path)
+	}
+
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%w: %q must be a mapping", errSDLIncludeInvalid, path)
+	}
+
+	for i := 0; i < len(src.Content); i += 2 {
+		key := src.Content[i]
+		val := src.Content[i+1]
+
+		idx := mappingIndex(dst, key.Value)
+		if idx < 0 {
+			dst.Content = append(dst.Content, key, val)
+			continue
+		}
+
+		merged, err := mergeMapping(dst.Content[idx+1], val, depth-1, path+"."+key.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		dst.Content[idx+1] = merged
+	}
+
+	return dst, nil
+}
+
+func mappingIndex(node *yaml.Node, key string) int {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func isNullNode(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!null"
+}
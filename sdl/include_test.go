@@ -0,0 +1,84 @@
+package sdl
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSDLInclude(t *testing.T) {
+	expected, err := ReadFile("_testdata/simple.yaml")
+	require.NoError(t, err)
+
+	obj, err := ReadFile("_testdata/include/main.yaml")
+	require.NoError(t, err)
+
+	expectedGroups, err := expected.DeploymentGroups()
+	require.NoError(t, err)
+
+	groups, err := obj.DeploymentGroups()
+	require.NoError(t, err)
+	require.Equal(t, expectedGroups, groups)
+
+	expectedManifest, err := expected.Manifest()
+	require.NoError(t, err)
+
+	m, err := obj.Manifest()
+	require.NoError(t, err)
+	require.Equal(t, expectedManifest, m)
+
+	expectedVersion, err := expected.Version()
+	require.NoError(t, err)
+
+	version, err := obj.Version()
+	require.NoError(t, err)
+	require.Equal(t, expectedVersion, version)
+}
+
+func TestSDLIncludeConflict(t *testing.T) {
+	_, err := ReadFile("_testdata/include/conflict.yaml")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, errSDLIncludeConflict))
+	require.Contains(t, err.Error(), `"profiles.compute.web" already defined`)
+}
+
+func TestSDLIncludeVersionMismatch(t *testing.T) {
+	_, err := ReadFile("_testdata/include/version-mismatch.yaml")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, errSDLIncludeConflict))
+}
+
+func TestSDLIncludeCycle(t *testing.T) {
+	_, err := ReadFile("_testdata/include/cycle-a.yaml")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, errSDLIncludeCycle))
+}
+
+func TestSDLIncludeMissingFile(t *testing.T) {
+	_, err := ReadFile("_testdata/include/missing.yaml")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, os.ErrNotExist))
+}
+
+func TestSDLIncludeIgnoredByRead(t *testing.T) {
+	buf, err := os.ReadFile("_testdata/simple.yaml")
+	require.NoError(t, err)
+
+	expected, err := Read(buf)
+	require.NoError(t, err)
+
+	buf = bytes.Replace(buf, []byte("version: \"2.0\"\n"), []byte("version: \"2.0\"\ninclude:\n  - missing.yaml\n"), 1)
+
+	sdl, err := Read(buf)
+	require.NoError(t, err)
+
+	expectedGroups, err := expected.DeploymentGroups()
+	require.NoError(t, err)
+
+	groups, err := sdl.DeploymentGroups()
+	require.NoError(t, err)
+	require.Equal(t, expectedGroups, groups)
+}
@@ -3,13 +3,14 @@ package sdl
 import (
 	"errors"
 	"fmt"
-	"os"
 
 	"github.com/blang/semver/v4"
 	"gopkg.in/yaml.v3"
 
 	manifest "github.com/akash-network/akash-api/go/manifest/v2beta2"
 	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+
+	"github.com/akash-network/node/util/attributes"
 )
 
 const (
@@ -31,6 +32,30 @@ type SDL interface {
 
 var _ SDL = (*sdl)(nil)
 
+type readOptions struct {
+	attributesMode attributes.Mode
+	onWarning      func(error)
+}
+
+// ReadOption configures how SDL is read and validated
+type ReadOption func(*readOptions)
+
+// WithAttributesMode sets mode placement attributes are checked against
+// the well-known provider attribute registry. Defaults to attributes.ModeWarn
+func WithAttributesMode(mode attributes.Mode) ReadOption {
+	return func(opts *readOptions) {
+		opts.attributesMode = mode
+	}
+}
+
+// WithAttributesWarnings sets handler invoked for every placement attribute
+// not matching the registry when reading in attributes.ModeWarn
+func WithAttributesWarnings(fn func(error)) ReadOption {
+	return func(opts *readOptions) {
+		opts.onWarning = fn
+	}
+}
+
 type sdl struct {
 	Ver  semver.Version `yaml:"version,-"`
 	data SDL            `yaml:"-"`
@@ -79,17 +104,28 @@ func (s *sdl) UnmarshalYAML(node *yaml.Node) error {
 	return nil
 }
 
-// ReadFile read from given path and returns SDL instance
-func ReadFile(path string) (SDL, error) {
-	buf, err := os.ReadFile(path)
+// ReadFile read from given path and returns SDL instance.
+// Files listed in include directive are resolved relative to the including file
+// and merged into the resulting SDL.
+func ReadFile(path string, opts ...ReadOption) (SDL, error) {
+	buf, err := readFileWithIncludes(path)
 	if err != nil {
 		return nil, err
 	}
-	return Read(buf)
+	return Read(buf, opts...)
 }
 
-// Read reads buffer data and returns SDL instance
-func Read(buf []byte) (SDL, error) {
+// Read reads buffer data and returns SDL instance.
+// Include directive is not resolved, use ReadFile to read SDL composed of multiple files.
+func Read(buf []byte, opts ...ReadOption) (SDL, error) {
+	ropts := &readOptions{
+		attributesMode: attributes.ModeWarn,
+	}
+
+	for _, opt := range opts {
+		opt(ropts)
+	}
+
 	obj := &sdl{}
 	if err := yaml.Unmarshal(buf, obj); err != nil {
 		return nil, err
@@ -106,6 +142,17 @@ func Read(buf []byte) (SDL, error) {
 
 	vgroups := make([]dtypes.GroupSpec, 0, len(dgroups))
 	for _, dgroup := range dgroups {
+		warnings, err := attributes.Validate(dgroup.Requirements.Attributes, ropts.attributesMode)
+		if err != nil {
+			return nil, fmt.Errorf("%w: placement %q: %w", errSDLInvalid, dgroup.Name, err)
+		}
+
+		if ropts.onWarning != nil {
+			for _, warning := range warnings {
+				ropts.onWarning(fmt.Errorf("placement %q: %w", dgroup.Name, warning))
+			}
+		}
+
 		vgroups = append(vgroups, *dgroup)
 	}
 
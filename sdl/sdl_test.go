@@ -1,9 +1,13 @@
 package sdl
 
 import (
+	"bytes"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/akash-network/node/util/attributes"
 )
 
 func TestSDLManifestVersion(t *testing.T) {
@@ -57,3 +61,30 @@ func TestSDLManifestVersionChangesWithVersion(t *testing.T) {
 	// Should be different from the first
 	require.NotEqual(t, secondVersion, version)
 }
+
+func TestSDLPlacementAttributesMode(t *testing.T) {
+	_, err := ReadFile("_testdata/simple.yaml", WithAttributesMode(attributes.ModeStrict))
+	require.NoError(t, err)
+
+	buf, err := os.ReadFile("_testdata/simple.yaml")
+	require.NoError(t, err)
+
+	buf = bytes.Replace(buf, []byte("region: us-west"), []byte("regoin: us-west"), 1)
+
+	// warn mode is the default and does not fail
+	_, err = Read(buf)
+	require.NoError(t, err)
+
+	var warnings []error
+	_, err = Read(buf, WithAttributesWarnings(func(warning error) {
+		warnings = append(warnings, warning)
+	}))
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	require.ErrorIs(t, warnings[0], attributes.ErrInvalidAttribute)
+	require.ErrorContains(t, warnings[0], `placement "westcoast"`)
+
+	_, err = Read(buf, WithAttributesMode(attributes.ModeStrict))
+	require.ErrorIs(t, err, errSDLInvalid)
+	require.ErrorIs(t, err, attributes.ErrInvalidAttribute)
+}
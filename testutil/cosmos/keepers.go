@@ -18,6 +18,7 @@ type BankKeeper interface {
 //go:generate mockery --name TakeKeeper --output ./mocks
 type TakeKeeper interface {
 	SubtractFees(ctx sdk.Context, amt sdk.Coin) (sdk.Coin, sdk.Coin, error)
+	AddFeesTaken(ctx sdk.Context, fee sdk.Coin)
 }
 
 //go:generate mockery --name DistrKeeper --output ./mocks
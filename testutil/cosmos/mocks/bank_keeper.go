@@ -20,6 +20,55 @@ func (_m *BankKeeper) EXPECT() *BankKeeper_Expecter {
 	return &BankKeeper_Expecter{mock: &_m.Mock}
 }
 
+// GetAllBalances provides a mock function with given fields: ctx, addr
+func (_m *BankKeeper) GetAllBalances(ctx types.Context, addr types.AccAddress) types.Coins {
+	ret := _m.Called(ctx, addr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllBalances")
+	}
+
+	var r0 types.Coins
+	if rf, ok := ret.Get(0).(func(types.Context, types.AccAddress) types.Coins); ok {
+		r0 = rf(ctx, addr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(types.Coins)
+		}
+	}
+
+	return r0
+}
+
+// BankKeeper_GetAllBalances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllBalances'
+type BankKeeper_GetAllBalances_Call struct {
+	*mock.Call
+}
+
+// GetAllBalances is a helper method to define mock.On call
+//   - ctx types.Context
+//   - addr types.AccAddress
+func (_e *BankKeeper_Expecter) GetAllBalances(ctx interface{}, addr interface{}) *BankKeeper_GetAllBalances_Call {
+	return &BankKeeper_GetAllBalances_Call{Call: _e.mock.On("GetAllBalances", ctx, addr)}
+}
+
+func (_c *BankKeeper_GetAllBalances_Call) Run(run func(ctx types.Context, addr types.AccAddress)) *BankKeeper_GetAllBalances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.Context), args[1].(types.AccAddress))
+	})
+	return _c
+}
+
+func (_c *BankKeeper_GetAllBalances_Call) Return(_a0 types.Coins) *BankKeeper_GetAllBalances_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *BankKeeper_GetAllBalances_Call) RunAndReturn(run func(types.Context, types.AccAddress) types.Coins) *BankKeeper_GetAllBalances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendCoinsFromAccountToModule provides a mock function with given fields: ctx, senderAddr, recipientModule, amt
 func (_m *BankKeeper) SendCoinsFromAccountToModule(ctx types.Context, senderAddr types.AccAddress, recipientModule string, amt types.Coins) error {
 	ret := _m.Called(ctx, senderAddr, recipientModule, amt)
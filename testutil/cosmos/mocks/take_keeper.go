@@ -20,6 +20,40 @@ func (_m *TakeKeeper) EXPECT() *TakeKeeper_Expecter {
 	return &TakeKeeper_Expecter{mock: &_m.Mock}
 }
 
+// AddFeesTaken provides a mock function with given fields: ctx, fee
+func (_m *TakeKeeper) AddFeesTaken(ctx types.Context, fee types.Coin) {
+	_m.Called(ctx, fee)
+}
+
+// TakeKeeper_AddFeesTaken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddFeesTaken'
+type TakeKeeper_AddFeesTaken_Call struct {
+	*mock.Call
+}
+
+// AddFeesTaken is a helper method to define mock.On call
+//   - ctx types.Context
+//   - fee types.Coin
+func (_e *TakeKeeper_Expecter) AddFeesTaken(ctx interface{}, fee interface{}) *TakeKeeper_AddFeesTaken_Call {
+	return &TakeKeeper_AddFeesTaken_Call{Call: _e.mock.On("AddFeesTaken", ctx, fee)}
+}
+
+func (_c *TakeKeeper_AddFeesTaken_Call) Run(run func(ctx types.Context, fee types.Coin)) *TakeKeeper_AddFeesTaken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(types.Context), args[1].(types.Coin))
+	})
+	return _c
+}
+
+func (_c *TakeKeeper_AddFeesTaken_Call) Return() *TakeKeeper_AddFeesTaken_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *TakeKeeper_AddFeesTaken_Call) RunAndReturn(run func(types.Context, types.Coin)) *TakeKeeper_AddFeesTaken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SubtractFees provides a mock function with given fields: ctx, amt
 func (_m *TakeKeeper) SubtractFees(ctx types.Context, amt types.Coin) (types.Coin, types.Coin, error) {
 	ret := _m.Called(ctx, amt)
@@ -3,6 +3,7 @@ package testutil
 import (
 	cryptorand "crypto/rand"
 	"crypto/sha256"
+	"math/big"
 	"math/rand"
 	"testing"
 
@@ -10,6 +11,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 
+	ctypes "github.com/akash-network/akash-api/go/node/cert/v1beta3"
 	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
 	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
 )
@@ -100,3 +102,11 @@ func LeaseIDForAccount(t testing.TB, owner, provider sdk.Address) mtypes.LeaseID
 	t.Helper()
 	return mtypes.MakeLeaseID(BidIDForAccount(t, owner, provider))
 }
+
+func CertID(t testing.TB) ctypes.CertID {
+	t.Helper()
+	return ctypes.CertID{
+		Owner:  AccAddress(t),
+		Serial: *new(big.Int).SetUint64(rand.Uint64()), // nolint: gosec
+	}
+}
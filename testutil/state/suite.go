@@ -98,7 +98,7 @@ func SetupTestSuiteWithKeepers(t testing.TB, keepers Keepers) *TestSuite {
 	}
 
 	if keepers.Escrow == nil {
-		keepers.Escrow = ekeeper.NewKeeper(etypes.ModuleCdc, app.GetKey(etypes.ModuleName), keepers.Bank, keepers.Take, keepers.Distr, keepers.Authz)
+		keepers.Escrow = ekeeper.NewKeeper(etypes.ModuleCdc, app.GetKey(etypes.ModuleName), app.GetSubspace(etypes.ModuleName), keepers.Bank, keepers.Take, keepers.Distr, keepers.Authz)
 	}
 	if keepers.Market == nil {
 		keepers.Market = mkeeper.NewKeeper(mtypes.ModuleCdc, app.GetKey(mtypes.ModuleName), app.GetSubspace(mtypes.ModuleName), keepers.Escrow)
@@ -147,6 +147,11 @@ func (ts *TestSuite) AuditKeeper() akeeper.IKeeper {
 	return ts.keepers.Audit
 }
 
+// TakeKeeper key store
+func (ts *TestSuite) TakeKeeper() tkeeper.IKeeper {
+	return ts.keepers.Take
+}
+
 // EscrowKeeper key store
 func (ts *TestSuite) EscrowKeeper() ekeeper.Keeper {
 	return ts.keepers.Escrow
@@ -0,0 +1,83 @@
+// Package v0_40_0
+// nolint revive
+package v0_40_0
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	sdkmodule "github.com/cosmos/cosmos-sdk/types/module"
+
+	types "github.com/akash-network/akash-api/go/node/cert/v1beta3"
+
+	utypes "github.com/akash-network/node/upgrades/types"
+	"github.com/akash-network/node/x/cert/keeper"
+)
+
+type certMigrations struct {
+	utypes.Migrator
+}
+
+func newCertMigration(m utypes.Migrator) utypes.Migration {
+	return certMigrations{Migrator: m}
+}
+
+func (m certMigrations) GetHandler() sdkmodule.MigrationHandler {
+	return m.handler
+}
+
+// handler migrates x/cert from version 3 to 4.
+// Valid certificates are indexed by x509 NotAfter for the expiration EndBlocker.
+func (m certMigrations) handler(ctx sdk.Context) error {
+	store := ctx.KVStore(m.StoreKey())
+
+	prefix := make([]byte, 0, len(keeper.CertPrefix)+len(keeper.CertStateValidPrefix))
+	prefix = append(prefix, keeper.CertPrefix...)
+	prefix = append(prefix, keeper.CertStateValidPrefix...)
+
+	var certs []kv.Pair
+
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iter.Valid(); iter.Next() {
+		_, id, err := keeper.ParseCertKey(iter.Key())
+		if err != nil {
+			_ = iter.Close()
+			return err
+		}
+
+		var val types.Certificate
+		m.Codec().MustUnmarshal(iter.Value(), &val)
+
+		block, _ := pem.Decode(val.Cert)
+		if block == nil {
+			_ = iter.Close()
+			return fmt.Errorf("%w: owner=%s serial=%s", types.ErrInvalidCertificateValue, id.Owner, id.Serial.String())
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			_ = iter.Close()
+			return err
+		}
+
+		key, err := keeper.CertificateExpiryKey(cert.NotAfter, id)
+		if err != nil {
+			_ = iter.Close()
+			return err
+		}
+
+		certs = append(certs, kv.Pair{Key: key, Value: iter.Key()})
+	}
+	_ = iter.Close()
+
+	for _, pair := range certs {
+		store.Set(pair.Key, pair.Value)
+	}
+
+	ctx.Logger().Info(fmt.Sprintf("[upgrade %s]: indexed x/cert valid certificates by expiration. total=%d", UpgradeName, len(certs)))
+
+	return nil
+}
@@ -0,0 +1,59 @@
+// Package v0_40_0
+// nolint revive
+package v0_40_0
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkmodule "github.com/cosmos/cosmos-sdk/types/module"
+
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+
+	utypes "github.com/akash-network/node/upgrades/types"
+	"github.com/akash-network/node/x/escrow/keeper"
+)
+
+type escrowMigrations struct {
+	utypes.Migrator
+}
+
+func newEscrowMigration(m utypes.Migrator) utypes.Migration {
+	return escrowMigrations{Migrator: m}
+}
+
+func (m escrowMigrations) GetHandler() sdkmodule.MigrationHandler {
+	return m.handler
+}
+
+// handler migrates escrow from version 2 to 3.
+// All open accounts are placed into settlement queue at upgrade height,
+// the EndBlocker settles and reschedules them within the per-block budget.
+func (m escrowMigrations) handler(ctx sdk.Context) error {
+	store := ctx.KVStore(m.StoreKey())
+	iter := sdk.KVStorePrefixIterator(store, etypes.AccountKeyPrefix())
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var total uint64
+
+	for ; iter.Valid(); iter.Next() {
+		var val etypes.Account
+		m.Codec().MustUnmarshal(iter.Value(), &val)
+
+		if val.State != etypes.AccountOpen {
+			continue
+		}
+
+		store.Set(keeper.SettlementQueueKey(ctx.BlockHeight(), val.ID), m.Codec().MustMarshal(&val.ID))
+		store.Set(keeper.SettlementScheduleKey(val.ID), sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())))
+
+		total++
+	}
+
+	ctx.Logger().Info(fmt.Sprintf("[upgrade %s]: scheduled x/escrow accounts for settlement. total=%d", UpgradeName, total))
+
+	return nil
+}
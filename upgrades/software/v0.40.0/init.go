@@ -0,0 +1,18 @@
+// Package v0_40_0
+// nolint revive
+package v0_40_0
+
+import (
+	ctypes "github.com/akash-network/akash-api/go/node/cert/v1beta3"
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	utypes "github.com/akash-network/node/upgrades/types"
+)
+
+func init() {
+	utypes.RegisterUpgrade(UpgradeName, initUpgrade)
+	utypes.RegisterMigration(ctypes.ModuleName, 3, newCertMigration)
+	utypes.RegisterMigration(etypes.ModuleName, 2, newEscrowMigration)
+	utypes.RegisterMigration(mtypes.ModuleName, 6, newMarketMigration)
+}
@@ -0,0 +1,78 @@
+// Package v0_40_0
+// nolint revive
+package v0_40_0
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/kv"
+	sdkmodule "github.com/cosmos/cosmos-sdk/types/module"
+
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	utypes "github.com/akash-network/node/upgrades/types"
+	"github.com/akash-network/node/x/market/keeper/keys/v1beta4"
+)
+
+type marketMigrations struct {
+	utypes.Migrator
+}
+
+func newMarketMigration(m utypes.Migrator) utypes.Migration {
+	return marketMigrations{Migrator: m}
+}
+
+func (m marketMigrations) GetHandler() sdkmodule.MigrationHandler {
+	return m.handler
+}
+
+// handler migrates market from version 6 to 7.
+// Open orders and bids are indexed by their creation height for the expiration EndBlocker.
+func (m marketMigrations) handler(ctx sdk.Context) error {
+	store := ctx.KVStore(m.StoreKey())
+
+	oprefix, err := v1beta4.OrderPrefixFromFilter(mtypes.OrderFilters{State: mtypes.OrderOpen.String()})
+	if err != nil {
+		return err
+	}
+
+	bprefix, err := v1beta4.BidPrefixFromFilter(mtypes.BidFilters{State: mtypes.BidOpen.String()})
+	if err != nil {
+		return err
+	}
+
+	var orders []kv.Pair
+
+	oiter := sdk.KVStorePrefixIterator(store, oprefix)
+	for ; oiter.Valid(); oiter.Next() {
+		var val mtypes.Order
+		m.Codec().MustUnmarshal(oiter.Value(), &val)
+
+		orders = append(orders, kv.Pair{Key: v1beta4.OrderOpenHeightKey(val.CreatedAt, val.OrderID), Value: oiter.Key()})
+	}
+	_ = oiter.Close()
+
+	var bids []kv.Pair
+
+	biter := sdk.KVStorePrefixIterator(store, bprefix)
+	for ; biter.Valid(); biter.Next() {
+		var val mtypes.Bid
+		m.Codec().MustUnmarshal(biter.Value(), &val)
+
+		bids = append(bids, kv.Pair{Key: v1beta4.BidOpenHeightKey(val.CreatedAt, val.BidID), Value: biter.Key()})
+	}
+	_ = biter.Close()
+
+	for _, pair := range orders {
+		store.Set(pair.Key, pair.Value)
+	}
+
+	for _, pair := range bids {
+		store.Set(pair.Key, pair.Value)
+	}
+
+	ctx.Logger().Info(fmt.Sprintf("[upgrade %s]: indexed x/market open orders and bids by creation height. orders=%d bids=%d", UpgradeName, len(orders), len(bids)))
+
+	return nil
+}
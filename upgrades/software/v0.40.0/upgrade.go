@@ -0,0 +1,111 @@
+// Package v0_40_0
+// nolint revive
+package v0_40_0
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	storetypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	apptypes "github.com/akash-network/node/app/types"
+	utypes "github.com/akash-network/node/upgrades/types"
+)
+
+const (
+	UpgradeName = "v0.40.0"
+)
+
+type upgrade struct {
+	*apptypes.App
+	log log.Logger
+}
+
+var _ utypes.IUpgrade = (*upgrade)(nil)
+
+func initUpgrade(log log.Logger, app *apptypes.App) (utypes.IUpgrade, error) {
+	up := &upgrade{
+		App: app,
+		log: log.With("module", fmt.Sprintf("upgrade/%s", UpgradeName)),
+	}
+
+	return up, nil
+}
+
+func (up *upgrade) StoreLoader() *storetypes.StoreUpgrades {
+	return &storetypes.StoreUpgrades{}
+}
+
+func (up *upgrade) UpgradeHandler() upgradetypes.UpgradeHandler {
+	return func(ctx sdk.Context, _ upgradetypes.Plan, fromVM module.VersionMap) (module.VersionMap, error) {
+		toVM, err := up.MM.RunMigrations(ctx, up.Configurator, fromVM)
+		if err != nil {
+			return toVM, err
+		}
+
+		// accounts closed within the same block they have been settled in left their payments open,
+		// close and withdraw such payments along with their leases
+		closed, err := up.Keepers.Akash.Escrow.CloseOrphanedPayments(ctx)
+		if err != nil {
+			return toVM, err
+		}
+
+		up.log.Info(fmt.Sprintf("closed orphaned x/escrow payments. total=%d", closed))
+
+		// market used to close only active orders of closed groups,
+		// close open orders left behind along with their bids
+		groups := up.closeOrphanedOrders(ctx)
+
+		up.log.Info(fmt.Sprintf("closed orphaned x/market orders. groups=%d", groups))
+
+		// price statistics index covers leases and bids created from now on only,
+		// index existing active leases and open bids
+		up.Keepers.Akash.Market.RebuildPriceIndex(ctx)
+
+		return toVM, nil
+	}
+}
+
+// closeOrphanedOrders closes open and active orders of groups which are not open.
+// It returns number of such groups.
+func (up *upgrade) closeOrphanedOrders(ctx sdk.Context) int {
+	gids := make(map[string]dtypes.GroupID)
+
+	up.Keepers.Akash.Market.WithOrders(ctx, func(order mtypes.Order) bool {
+		if order.State == mtypes.OrderClosed {
+			return false
+		}
+
+		gid := order.ID().GroupID()
+
+		if group, found := up.Keepers.Akash.Deployment.GetGroup(ctx, gid); found && group.State == dtypes.GroupOpen {
+			return false
+		}
+
+		gids[gid.String()] = gid
+
+		return false
+	})
+
+	keys := make([]string, 0, len(gids))
+	for key := range gids {
+		keys = append(keys, key)
+	}
+
+	// map iteration order is random, close groups in deterministic order
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		up.Keepers.Akash.Market.OnGroupClosed(ctx, gids[key])
+	}
+
+	return len(keys)
+}
@@ -3,4 +3,6 @@ package upgrades
 import (
 	// nolint: revive
 	_ "github.com/akash-network/node/upgrades/software/v0.38.0"
+	// nolint: revive
+	_ "github.com/akash-network/node/upgrades/software/v0.40.0"
 )
@@ -0,0 +1,300 @@
+// Package attributes defines registry of well-known provider attribute keys
+// along with the types and allowed values of the attributes.
+// Both provider attributes and SDL placement requirements are checked against it,
+// so typos such as "regoin" do not silently make providers unmatchable.
+package attributes
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+)
+
+var (
+	// ErrInvalidAttribute indicates attribute does not conform to the registry
+	ErrInvalidAttribute = errors.New("attributes: invalid attribute")
+	// ErrInvalidMode indicates unknown validation mode
+	ErrInvalidMode = errors.New("attributes: invalid mode")
+)
+
+// Type of the attribute value
+type Type int
+
+const (
+	// TypeString is any non-empty value, optionally matching Spec.Pattern
+	TypeString Type = iota
+	// TypeBool is either true or false
+	TypeBool
+	// TypeEnum is one of Spec.Values
+	TypeEnum
+)
+
+// Mode defines how attributes not conforming to the registry are handled
+type Mode int
+
+const (
+	// ModeWarn reports issues without rejecting attributes
+	ModeWarn Mode = iota
+	// ModeStrict rejects attributes with any issue
+	ModeStrict
+)
+
+const (
+	modeWarn   = "warn"
+	modeStrict = "strict"
+
+	// capabilitiesNamespace is reserved for well-known keys, any other key in it is an issue
+	capabilitiesNamespace = "capabilities/"
+
+	// maxTypoDistance is the maximum edit distance of unknown key to a well-known key to be reported as typo
+	maxTypoDistance = 2
+)
+
+// ParseMode returns mode of given name
+func ParseMode(val string) (Mode, error) {
+	switch val {
+	case modeWarn:
+		return ModeWarn, nil
+	case modeStrict:
+		return ModeStrict, nil
+	}
+
+	return ModeWarn, fmt.Errorf("%w: %q, expected %s or %s", ErrInvalidMode, val, modeWarn, modeStrict)
+}
+
+func (m Mode) String() string {
+	if m == ModeStrict {
+		return modeStrict
+	}
+
+	return modeWarn
+}
+
+// Spec describes a well-known attribute
+type Spec struct {
+	// Key is slash separated attribute key, "*" segment matches any single segment
+	Key string
+	// Prefix allows trailing segments after Key
+	Prefix bool
+	// Segments lists allowed values of wildcard key segments keyed by segment index
+	Segments map[int][]string
+	Type     Type
+	// Pattern optionally restricts TypeString values
+	Pattern *regexp.Regexp
+	// Values lists allowed values of TypeEnum attributes
+	Values []string
+}
+
+// GPUVendors lists GPU vendors accepted in capabilities/gpu/vendor/<vendor>/... attributes
+var GPUVendors = []string{"nvidia", "amd", "intel"}
+
+// StorageClasses lists storage classes accepted in capabilities/storage/<n>/class attributes
+var StorageClasses = []string{"default", "beta1", "beta2", "beta3", "ram"}
+
+// Registry lists all well-known attributes
+var Registry = []Spec{
+	{
+		Key:  "host",
+		Type: TypeString,
+	},
+	{
+		Key:  "organization",
+		Type: TypeString,
+	},
+	{
+		Key:     "region",
+		Type:    TypeString,
+		Pattern: regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`),
+	},
+	{
+		Key:    "tier",
+		Type:   TypeEnum,
+		Values: []string{"community", "premium"},
+	},
+	{
+		Key:  "capabilities/cpu",
+		Type: TypeString,
+	},
+	{
+		Key:  "capabilities/cpu/arch",
+		Type: TypeString,
+	},
+	{
+		Key:  "capabilities/memory",
+		Type: TypeString,
+	},
+	{
+		Key:    "capabilities/storage/*/class",
+		Type:   TypeEnum,
+		Values: StorageClasses,
+	},
+	{
+		Key:  "capabilities/storage/*/persistent",
+		Type: TypeBool,
+	},
+	{
+		Key:      "capabilities/gpu/vendor/*/model/*",
+		Prefix:   true,
+		Segments: map[int][]string{3: GPUVendors},
+		Type:     TypeBool,
+	},
+}
+
+// Check returns issues found in attributes, nil if all of them conform to the registry.
+// Keys outside the registry are accepted unless they are in the reserved capabilities
+// namespace or are likely a typo of a well-known key.
+func Check(attrs types.Attributes) []error {
+	var issues []error
+
+	for _, attr := range attrs {
+		if err := checkAttribute(attr); err != nil {
+			issues = append(issues, err)
+		}
+	}
+
+	return issues
+}
+
+// Validate checks attributes according to mode. In strict mode issues are returned as error,
+// in warn mode they are returned as warnings and error is nil.
+func Validate(attrs types.Attributes, mode Mode) ([]error, error) {
+	issues := Check(attrs)
+
+	if mode == ModeStrict && len(issues) > 0 {
+		return nil, errors.Join(issues...)
+	}
+
+	return issues, nil
+}
+
+func checkAttribute(attr types.Attribute) error {
+	for _, spec := range Registry {
+		matched, err := spec.matchKey(attr.Key)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidAttribute, attr.Key, err)
+		}
+
+		if !matched {
+			continue
+		}
+
+		if err := spec.checkValue(attr.Value); err != nil {
+			return fmt.Errorf("%w: %s: %s", ErrInvalidAttribute, attr.Key, err)
+		}
+
+		return nil
+	}
+
+	if strings.HasPrefix(attr.Key, capabilitiesNamespace) {
+		return fmt.Errorf("%w: %s: unknown capability", ErrInvalidAttribute, attr.Key)
+	}
+
+	if known := suggestKey(attr.Key); known != "" {
+		return fmt.Errorf("%w: %s: unknown key, did you mean %q", ErrInvalidAttribute, attr.Key, known)
+	}
+
+	return nil
+}
+
+// matchKey returns true if key matches spec key. Error is returned when key matches spec
+// but one of its wildcard segments holds value not allowed by the spec.
+func (spec Spec) matchKey(key string) (bool, error) {
+	pattern := strings.Split(spec.Key, "/")
+	parts := strings.Split(key, "/")
+
+	if len(parts) < len(pattern) || (!spec.Prefix && len(parts) != len(pattern)) {
+		return false, nil
+	}
+
+	for idx, segment := range pattern {
+		if segment != "*" && segment != parts[idx] {
+			return false, nil
+		}
+	}
+
+	for idx, allowed := range spec.Segments {
+		if !contains(allowed, parts[idx]) {
+			return true, fmt.Errorf("unknown value %q of key segment %d, expected one of %s", parts[idx], idx, strings.Join(allowed, ", "))
+		}
+	}
+
+	return true, nil
+}
+
+func (spec Spec) checkValue(val string) error {
+	switch spec.Type {
+	case TypeBool:
+		if val != "true" && val != "false" {
+			return fmt.Errorf("invalid value %q, expected true or false", val)
+		}
+	case TypeEnum:
+		if !contains(spec.Values, val) {
+			return fmt.Errorf("invalid value %q, expected one of %s", val, strings.Join(spec.Values, ", "))
+		}
+	default:
+		if val == "" {
+			return errors.New("empty value")
+		}
+
+		if spec.Pattern != nil && !spec.Pattern.MatchString(val) {
+			return fmt.Errorf("invalid value %q, expected to match %s", val, spec.Pattern)
+		}
+	}
+
+	return nil
+}
+
+// suggestKey returns well-known key given key is likely a typo of
+func suggestKey(key string) string {
+	for _, spec := range Registry {
+		if strings.Contains(spec.Key, "/") {
+			continue
+		}
+
+		if dist := distance(strings.ToLower(key), spec.Key); dist <= maxTypoDistance && dist < len(spec.Key)/2 {
+			return spec.Key
+		}
+	}
+
+	return ""
+}
+
+// distance returns Levenshtein distance between a and b
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
+
+func contains(vals []string, val string) bool {
+	for _, v := range vals {
+		if v == val {
+			return true
+		}
+	}
+
+	return false
+}
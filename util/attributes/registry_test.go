@@ -0,0 +1,80 @@
+package attributes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		attr  types.Attribute
+		valid bool
+	}{
+		{types.Attribute{Key: "region", Value: "us-west"}, true},
+		{types.Attribute{Key: "region", Value: "US West"}, false},
+		{types.Attribute{Key: "regoin", Value: "us-west"}, false},
+		{types.Attribute{Key: "Region", Value: "us-west"}, false},
+		{types.Attribute{Key: "host", Value: "akash"}, true},
+		{types.Attribute{Key: "host", Value: ""}, false},
+		{types.Attribute{Key: "tier", Value: "community"}, true},
+		{types.Attribute{Key: "tier", Value: "gold"}, false},
+		{types.Attribute{Key: "moniker", Value: "akash"}, true},
+		{types.Attribute{Key: "location-region", Value: "us-west"}, true},
+		{types.Attribute{Key: "capabilities/storage/1/class", Value: "beta2"}, true},
+		{types.Attribute{Key: "capabilities/storage/1/class", Value: "beta4"}, false},
+		{types.Attribute{Key: "capabilities/storage/1/persistent", Value: "true"}, true},
+		{types.Attribute{Key: "capabilities/storage/1/persistent", Value: "yes"}, false},
+		{types.Attribute{Key: "capabilities/storage/1/persistnt", Value: "true"}, false},
+		{types.Attribute{Key: "capabilities/gpu/vendor/nvidia/model/a100", Value: "true"}, true},
+		{types.Attribute{Key: "capabilities/gpu/vendor/nvidia/model/a100/ram/80Gi", Value: "true"}, true},
+		{types.Attribute{Key: "capabilities/gpu/vendor/nvida/model/a100", Value: "true"}, false},
+		{types.Attribute{Key: "capabilities/gpu/vendor/amd", Value: "true"}, false},
+	}
+
+	for _, test := range tests {
+		issues := Check(types.Attributes{test.attr})
+		if test.valid {
+			require.Empty(t, issues, test.attr)
+		} else {
+			require.Len(t, issues, 1, test.attr)
+			require.ErrorIs(t, issues[0], ErrInvalidAttribute)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	attrs := types.Attributes{
+		{Key: "regoin", Value: "us-west"},
+		{Key: "tier", Value: "community"},
+		{Key: "tier", Value: "gold"},
+	}
+
+	warnings, err := Validate(attrs, ModeWarn)
+	require.NoError(t, err)
+	require.Len(t, warnings, 2)
+
+	warnings, err = Validate(attrs, ModeStrict)
+	require.ErrorIs(t, err, ErrInvalidAttribute)
+	require.ErrorContains(t, err, `did you mean "region"`)
+	require.Empty(t, warnings)
+
+	warnings, err = Validate(types.Attributes{{Key: "region", Value: "us-west"}}, ModeStrict)
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+}
+
+func TestParseMode(t *testing.T) {
+	mode, err := ParseMode("strict")
+	require.NoError(t, err)
+	require.Equal(t, ModeStrict, mode)
+
+	mode, err = ParseMode("warn")
+	require.NoError(t, err)
+	require.Equal(t, ModeWarn, mode)
+
+	_, err = ParseMode("lenient")
+	require.ErrorIs(t, err, ErrInvalidMode)
+}
@@ -3,6 +3,7 @@ package cli
 import (
 	"fmt"
 	"math/big"
+	"time"
 
 	sdkclient "github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
@@ -12,6 +13,8 @@ import (
 	types "github.com/akash-network/akash-api/go/node/cert/v1beta3"
 
 	aclient "github.com/akash-network/node/client"
+	"github.com/akash-network/node/x/cert/keeper"
+	"github.com/akash-network/node/x/cert/query"
 )
 
 const (
@@ -29,6 +32,7 @@ func GetQueryCmd() *cobra.Command {
 
 	cmd.AddCommand(
 		cmdGetCertificates(),
+		cmdGetCertificatesExpiring(),
 	)
 
 	return cmd
@@ -108,3 +112,64 @@ func cmdGetCertificates() *cobra.Command {
 
 	return cmd
 }
+
+func cmdGetCertificatesExpiring() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "expiring",
+		Short:        "Query for valid certificates expiring within time window",
+		Args:         cobra.ExactArgs(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := sdkclient.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			req := keeper.QueryCertificatesExpiringRequest{
+				After: time.Now().UTC(),
+			}
+
+			if value := cmd.Flag("owner").Value.String(); value != "" {
+				var owner sdk.Address
+				if owner, err = sdk.AccAddressFromBech32(value); err != nil {
+					return err
+				}
+
+				req.Owner = owner.String()
+			}
+
+			if value := cmd.Flag("after").Value.String(); value != "" {
+				if req.After, err = time.Parse(time.RFC3339, value); err != nil {
+					return err
+				}
+			}
+
+			within, err := cmd.Flags().GetDuration("within")
+			if err != nil {
+				return err
+			}
+
+			req.Before = req.After.Add(within)
+
+			if req.Limit, err = cmd.Flags().GetUint64(flags.FlagLimit); err != nil {
+				return err
+			}
+
+			res, err := query.NewClient(cctx, types.StoreKey).CertificatesExpiring(req)
+			if err != nil {
+				return err
+			}
+
+			return cctx.PrintProto(&res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	cmd.Flags().String("owner", "", "filter certificates by owner")
+	cmd.Flags().String("after", "", "start of the expiry window in RFC3339 format. defaults to current time")
+	cmd.Flags().Duration("within", 30*24*time.Hour, "length of the expiry window")
+	cmd.Flags().Uint64(flags.FlagLimit, 100, "maximum number of certificates to return")
+
+	return cmd
+}
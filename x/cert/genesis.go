@@ -42,16 +42,22 @@ func InitGenesis(ctx sdk.Context, kpr keeper.Keeper, data *types.GenesisState) [
 			panic(err.Error())
 		}
 
-		key := keeper.MustCertificateKey(record.Certificate.State, types.CertID{
+		id := types.CertID{
 			Owner:  owner,
 			Serial: *cert.SerialNumber,
-		})
+		}
+
+		key := keeper.MustCertificateKey(record.Certificate.State, id)
 
 		if store.Has(key) {
 			panic(types.ErrCertificateExists.Error())
 		}
 
 		store.Set(key, cdc.MustMarshal(&record.Certificate))
+
+		if record.Certificate.State == types.CertificateValid {
+			store.Set(keeper.MustCertificateExpiryKey(cert.NotAfter, id), key)
+		}
 	}
 
 	return []abci.ValidatorUpdate{}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "github.com/akash-network/akash-api/go/node/cert/v1beta3"
+
+	"github.com/akash-network/node/x/cert/keeper"
+)
+
+// ExpirationBlockLimit is the maximum number of certificates revoked by the EndBlocker in a single block
+const ExpirationBlockLimit = 100
+
+// EndBlocker revokes valid certificates which x509 NotAfter has passed by the block time.
+// At most ExpirationBlockLimit certificates are revoked per block, earliest expired first,
+// the backlog is revoked in following blocks. Index entries of certificates which can not be
+// revoked are dropped, so they do not hold the head of the index.
+func EndBlocker(ctx sdk.Context, keeper keeper.Keeper) {
+	type expiry struct {
+		id       types.CertID
+		notAfter time.Time
+	}
+
+	var items []expiry
+
+	// collect first, store must not be modified while iterating
+	keeper.WithCertificatesExpiring(ctx, time.Unix(0, 0), ctx.BlockTime(), func(id types.CertID, notAfter time.Time, _ types.CertificateResponse) bool {
+		items = append(items, expiry{id: id, notAfter: notAfter})
+		return len(items) >= ExpirationBlockLimit
+	})
+
+	for _, item := range items {
+		id := item.id
+
+		if err := keeper.ExpireCertificate(ctx, id); err != nil {
+			keeper.DeleteCertificateExpiry(ctx, item.notAfter, id)
+			ctx.Logger().Error("expiring certificate, dropped from expiry index", "err", err, "owner", id.Owner, "serial", id.Serial.String())
+			continue
+		}
+
+		ctx.Logger().Info("expired certificate", "owner", id.Owner, "serial", id.Serial.String())
+	}
+}
@@ -3,6 +3,7 @@ package handler_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/store"
 	sdktestdata "github.com/cosmos/cosmos-sdk/testutil/testdata"
@@ -312,3 +313,118 @@ func TestCertHandlerRevokeCreate(t *testing.T) {
 	require.NotNil(t, res)
 	require.NoError(t, err)
 }
+
+func TestCertEndBlockerExpires(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	owner := testutil.AccAddress(t)
+	now := time.Now().UTC()
+
+	expired := testutil.Certificate(t, owner,
+		testutil.CertificateOptionNotBefore(now.Add(-2*time.Hour)),
+		testutil.CertificateOptionNotAfter(now.Add(-time.Hour)))
+	valid := testutil.Certificate(t, owner)
+
+	for _, cert := range []testutil.TestCertificate{expired, valid} {
+		err := suite.keeper.CreateCertificate(suite.ctx, owner, cert.PEM.Cert, cert.PEM.Pub)
+		require.NoError(t, err)
+	}
+
+	handler.EndBlocker(suite.ctx.WithBlockTime(now), suite.keeper)
+
+	resp, exists := suite.keeper.GetCertificateByID(suite.ctx, types.CertID{
+		Owner:  owner,
+		Serial: expired.Serial,
+	})
+	require.True(t, exists)
+	testutil.CertificateRequireEqualResponse(t, expired, resp, types.CertificateRevoked)
+
+	resp, exists = suite.keeper.GetCertificateByID(suite.ctx, types.CertID{
+		Owner:  owner,
+		Serial: valid.Serial,
+	})
+	require.True(t, exists)
+	testutil.CertificateRequireEqualResponse(t, valid, resp, types.CertificateValid)
+}
+
+func TestCertEndBlockerExpirationLimit(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	owner := testutil.AccAddress(t)
+	now := time.Now().UTC()
+
+	for i := 0; i < handler.ExpirationBlockLimit+1; i++ {
+		cert := testutil.Certificate(t, owner,
+			testutil.CertificateOptionNotBefore(now.Add(-2*time.Hour)),
+			testutil.CertificateOptionNotAfter(now.Add(-time.Hour)))
+
+		err := suite.keeper.CreateCertificate(suite.ctx, owner, cert.PEM.Cert, cert.PEM.Pub)
+		require.NoError(t, err)
+	}
+
+	expiring := func() int {
+		count := 0
+		suite.keeper.WithCertificatesExpiring(suite.ctx, time.Unix(0, 0), now, func(types.CertID, time.Time, types.CertificateResponse) bool {
+			count++
+			return false
+		})
+
+		return count
+	}
+
+	handler.EndBlocker(suite.ctx.WithBlockTime(now), suite.keeper)
+	require.Equal(t, 1, expiring())
+
+	handler.EndBlocker(suite.ctx.WithBlockTime(now), suite.keeper)
+	require.Equal(t, 0, expiring())
+}
+
+func TestCertEndBlockerDropsStaleExpiry(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	owner := testutil.AccAddress(t)
+	now := time.Now().UTC()
+
+	// index entries of certificates missing from the store can not be expired
+	kvstore := suite.ctx.KVStore(suite.keeper.StoreKey())
+	for i := 0; i < handler.ExpirationBlockLimit; i++ {
+		id := types.CertID{
+			Owner:  owner,
+			Serial: testutil.Certificate(t, owner).Serial,
+		}
+
+		kvstore.Set(keeper.MustCertificateExpiryKey(now.Add(-2*time.Hour), id), keeper.MustCertificateKey(types.CertificateValid, id))
+	}
+
+	expired := testutil.Certificate(t, owner,
+		testutil.CertificateOptionNotBefore(now.Add(-2*time.Hour)),
+		testutil.CertificateOptionNotAfter(now.Add(-time.Hour)))
+
+	err := suite.keeper.CreateCertificate(suite.ctx, owner, expired.PEM.Cert, expired.PEM.Pub)
+	require.NoError(t, err)
+
+	expiring := func() int {
+		count := 0
+		suite.keeper.WithCertificatesExpiring(suite.ctx, time.Unix(0, 0), now, func(types.CertID, time.Time, types.CertificateResponse) bool {
+			count++
+			return false
+		})
+
+		return count
+	}
+
+	require.Equal(t, handler.ExpirationBlockLimit+1, expiring())
+
+	handler.EndBlocker(suite.ctx.WithBlockTime(now), suite.keeper)
+	require.Equal(t, 1, expiring())
+
+	handler.EndBlocker(suite.ctx.WithBlockTime(now), suite.keeper)
+	require.Equal(t, 0, expiring())
+
+	resp, exists := suite.keeper.GetCertificateByID(suite.ctx, types.CertID{
+		Owner:  owner,
+		Serial: expired.Serial,
+	})
+	require.True(t, exists)
+	testutil.CertificateRequireEqualResponse(t, expired, resp, types.CertificateRevoked)
+}
@@ -2,6 +2,7 @@ package keeper
 
 import (
 	"context"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -12,12 +13,26 @@ import (
 	types "github.com/akash-network/akash-api/go/node/cert/v1beta3"
 )
 
+// Querier is the cert query server extended with queries the cert Query service does not define
+type Querier interface {
+	types.QueryServer
+	CertificatesExpiring(context.Context, *QueryCertificatesExpiringRequest) (*types.QueryCertificatesResponse, error)
+}
+
+// QueryCertificatesExpiringRequest selects valid certificates which x509 NotAfter is within [After, Before) window
+type QueryCertificatesExpiringRequest struct {
+	Owner  string    `json:"owner"`
+	After  time.Time `json:"after"`
+	Before time.Time `json:"before"`
+	Limit  uint64    `json:"limit"`
+}
+
 // Querier is used as Keeper will have duplicate methods if used directly, and gRPC names take precedence over keeper
 type querier struct {
 	keeper
 }
 
-var _ types.QueryServer = &querier{}
+var _ Querier = &querier{}
 
 func (q querier) Certificates(c context.Context, req *types.QueryCertificatesRequest) (*types.QueryCertificatesResponse, error) {
 	ctx := sdk.UnwrapSDKContext(c)
@@ -132,3 +147,46 @@ func (q querier) Certificates(c context.Context, req *types.QueryCertificatesReq
 		Pagination:   pageRes,
 	}, nil
 }
+
+// CertificatesExpiring returns valid certificates which x509 NotAfter is within requested window
+func (q querier) CertificatesExpiring(c context.Context, req *QueryCertificatesExpiringRequest) (*types.QueryCertificatesResponse, error) {
+	ctx := sdk.UnwrapSDKContext(c)
+
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if !req.After.Before(req.Before) {
+		return nil, status.Error(codes.InvalidArgument, "invalid expiry window. after must precede before")
+	}
+
+	var owner sdk.AccAddress
+
+	if req.Owner != "" {
+		var err error
+		if owner, err = sdk.AccAddressFromBech32(req.Owner); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = sdkquery.DefaultLimit
+	}
+
+	var certificates types.CertificatesResponse
+
+	q.WithCertificatesExpiring(ctx, req.After, req.Before, func(id types.CertID, _ time.Time, certificate types.CertificateResponse) bool {
+		if !owner.Empty() && !owner.Equals(id.Owner) {
+			return false
+		}
+
+		certificates = append(certificates, certificate)
+
+		return uint64(len(certificates)) == limit
+	})
+
+	return &types.QueryCertificatesResponse{
+		Certificates: certificates,
+	}, nil
+}
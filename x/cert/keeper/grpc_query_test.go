@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -253,3 +254,57 @@ func TestCertGRPCQueryCertificates(t *testing.T) {
 		})
 	}
 }
+
+func TestCertGRPCQueryCertificatesExpiring(t *testing.T) {
+	suite := setupTest(t)
+
+	now := time.Now().UTC()
+
+	owner := testutil.AccAddress(t)
+	cert := testutil.Certificate(t, owner, testutil.CertificateOptionNotAfter(now.Add(time.Hour)))
+
+	owner2 := testutil.AccAddress(t)
+	cert2 := testutil.Certificate(t, owner2, testutil.CertificateOptionNotAfter(now.Add(2*time.Hour)))
+
+	err := suite.keeper.CreateCertificate(suite.ctx, owner, cert.PEM.Cert, cert.PEM.Pub)
+	require.NoError(t, err)
+
+	err = suite.keeper.CreateCertificate(suite.ctx, owner2, cert2.PEM.Cert, cert2.PEM.Pub)
+	require.NoError(t, err)
+
+	querier := suite.keeper.Querier()
+	ctx := sdk.WrapSDKContext(suite.ctx)
+
+	res, err := querier.CertificatesExpiring(ctx, &keeper.QueryCertificatesExpiringRequest{
+		After:  now,
+		Before: now.Add(3 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Certificates, 2)
+	require.Equal(t, cert.Serial.String(), res.Certificates[0].Serial)
+	require.Equal(t, cert2.Serial.String(), res.Certificates[1].Serial)
+
+	res, err = querier.CertificatesExpiring(ctx, &keeper.QueryCertificatesExpiringRequest{
+		Owner:  owner2.String(),
+		After:  now,
+		Before: now.Add(3 * time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Certificates, 1)
+	require.Equal(t, cert2.Serial.String(), res.Certificates[0].Serial)
+
+	res, err = querier.CertificatesExpiring(ctx, &keeper.QueryCertificatesExpiringRequest{
+		After:  now,
+		Before: now.Add(3 * time.Hour),
+		Limit:  1,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Certificates, 1)
+	require.Equal(t, cert.Serial.String(), res.Certificates[0].Serial)
+
+	_, err = querier.CertificatesExpiring(ctx, &keeper.QueryCertificatesExpiringRequest{
+		After:  now,
+		Before: now,
+	})
+	require.Error(t, err)
+}
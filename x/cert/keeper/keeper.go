@@ -1,24 +1,33 @@
 package keeper
 
 import (
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	types "github.com/akash-network/akash-api/go/node/cert/v1beta3"
+
+	ctypes "github.com/akash-network/node/x/cert/types"
 )
 
 // Keeper of the provider store
 type Keeper interface {
-	Querier() types.QueryServer
+	Querier() Querier
 	Codec() codec.BinaryCodec
 	StoreKey() sdk.StoreKey
 	CreateCertificate(sdk.Context, sdk.Address, []byte, []byte) error
 	RevokeCertificate(sdk.Context, types.CertID) error
+	ExpireCertificate(sdk.Context, types.CertID) error
 	GetCertificateByID(ctx sdk.Context, id types.CertID) (types.CertificateResponse, bool)
 	WithCertificates(ctx sdk.Context, fn func(id types.CertID, certificate types.CertificateResponse) bool)
 	WithCertificatesState(ctx sdk.Context, state types.Certificate_State, fn func(certificate types.CertificateResponse) bool)
 	WithOwner(ctx sdk.Context, id sdk.Address, fn func(types.CertificateResponse) bool)
 	WithOwnerState(ctx sdk.Context, id sdk.Address, state types.Certificate_State, fn func(types.CertificateResponse) bool)
+	WithCertificatesExpiring(ctx sdk.Context, after, before time.Time, fn func(id types.CertID, notAfter time.Time, certificate types.CertificateResponse) bool)
+	DeleteCertificateExpiry(ctx sdk.Context, notAfter time.Time, id types.CertID)
 }
 
 type keeper struct {
@@ -34,7 +43,7 @@ func NewKeeper(cdc codec.BinaryCodec, skey sdk.StoreKey) Keeper {
 }
 
 // Querier return gRPC query handler
-func (k keeper) Querier() types.QueryServer {
+func (k keeper) Querier() Querier {
 	return &querier{keeper: k}
 }
 
@@ -77,11 +86,41 @@ func (k keeper) CreateCertificate(ctx sdk.Context, owner sdk.Address, crt []byte
 	}
 
 	store.Set(key, k.cdc.MustMarshal(&val))
+	store.Set(MustCertificateExpiryKey(cert.NotAfter, id), key)
+
+	ctx.EventManager().EmitEvent(
+		ctypes.NewEventCertificateCreated(id, val.State).ToSDKEvent(),
+	)
 
 	return nil
 }
 
 func (k keeper) RevokeCertificate(ctx sdk.Context, id types.CertID) error {
+	if err := k.revokeCertificate(ctx, id); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		ctypes.NewEventCertificateRevoked(id, types.CertificateRevoked).ToSDKEvent(),
+	)
+
+	return nil
+}
+
+// ExpireCertificate revokes valid certificate which x509 NotAfter has passed
+func (k keeper) ExpireCertificate(ctx sdk.Context, id types.CertID) error {
+	if err := k.revokeCertificate(ctx, id); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		ctypes.NewEventCertificateExpired(id, types.CertificateRevoked).ToSDKEvent(),
+	)
+
+	return nil
+}
+
+func (k keeper) revokeCertificate(ctx sdk.Context, id types.CertID) error {
 	store := ctx.KVStore(k.skey)
 	key := k.findCertificate(ctx, id)
 	if len(key) == 0 {
@@ -97,6 +136,11 @@ func (k keeper) RevokeCertificate(ctx sdk.Context, id types.CertID) error {
 		return types.ErrCertificateAlreadyRevoked
 	}
 
+	notAfter, err := certificateNotAfter(cert.Cert)
+	if err != nil {
+		return err
+	}
+
 	cert.State = types.CertificateRevoked
 
 	nkey, err := CertificateKey(cert.State, id)
@@ -105,6 +149,7 @@ func (k keeper) RevokeCertificate(ctx sdk.Context, id types.CertID) error {
 	}
 
 	store.Delete(key)
+	store.Delete(MustCertificateExpiryKey(notAfter, id))
 	store.Set(nkey, k.cdc.MustMarshal(&cert))
 
 	return nil
@@ -234,6 +279,33 @@ func (k keeper) WithOwnerState(ctx sdk.Context, id sdk.Address, state types.Cert
 	}
 }
 
+// WithCertificatesExpiring iterates valid certificates which x509 NotAfter is within [after, before) window.
+// notAfter passed to fn is the one certificate is indexed with
+func (k keeper) WithCertificatesExpiring(ctx sdk.Context, after, before time.Time, fn func(id types.CertID, notAfter time.Time, certificate types.CertificateResponse) bool) {
+	store := ctx.KVStore(k.skey)
+	iter := store.Iterator(certExpiryPrefix(after), certExpiryPrefix(before))
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Value()
+
+		id, item := k.mustUnmarshal(key, store.Get(key))
+		if stop := fn(id, certExpiryNotAfter(iter.Key()), item); stop {
+			break
+		}
+	}
+}
+
+// DeleteCertificateExpiry removes certificate entry from the expiry index.
+// Certificate itself is left intact
+func (k keeper) DeleteCertificateExpiry(ctx sdk.Context, notAfter time.Time, id types.CertID) {
+	store := ctx.KVStore(k.skey)
+	store.Delete(MustCertificateExpiryKey(notAfter, id))
+}
+
 func (k keeper) unmarshal(key, val []byte) (types.CertID, types.CertificateResponse, error) {
 	_, id, err := ParseCertKey(key)
 	if err != nil {
@@ -276,3 +348,17 @@ func (k keeper) findCertificate(ctx sdk.Context, id types.CertID) []byte {
 
 	return key
 }
+
+func certificateNotAfter(crt []byte) (time.Time, error) {
+	block, _ := pem.Decode(crt)
+	if block == nil {
+		return time.Time{}, types.ErrInvalidCertificateValue
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return cert.NotAfter, nil
+}
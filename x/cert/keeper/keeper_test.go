@@ -7,13 +7,16 @@ import (
 	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
 	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
 	dbm "github.com/tendermint/tm-db"
 
 	types "github.com/akash-network/akash-api/go/node/cert/v1beta3"
+	"github.com/akash-network/akash-api/go/sdkutil"
 
 	"github.com/akash-network/node/testutil"
 	"github.com/akash-network/node/x/cert/keeper"
+	ctypes "github.com/akash-network/node/x/cert/types"
 )
 
 func TestCertKeeperCreate(t *testing.T) {
@@ -188,6 +191,133 @@ func TestCertKeeperRevokeCreate(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestCertKeeperExpiring(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	owner := testutil.AccAddress(t)
+
+	now := time.Now().UTC().Truncate(time.Second)
+
+	cert1 := testutil.Certificate(t, owner, testutil.CertificateOptionNotAfter(now.Add(time.Hour)))
+	cert2 := testutil.Certificate(t, owner, testutil.CertificateOptionNotAfter(now.Add(48*time.Hour)))
+
+	require.NoError(t, keeper.CreateCertificate(ctx, owner, cert1.PEM.Cert, cert1.PEM.Pub))
+	require.NoError(t, keeper.CreateCertificate(ctx, owner, cert2.PEM.Cert, cert2.PEM.Pub))
+
+	expiring := func(after, before time.Time) []string {
+		var res []string
+		keeper.WithCertificatesExpiring(ctx, after, before, func(id types.CertID, _ time.Time, _ types.CertificateResponse) bool {
+			res = append(res, id.Serial.String())
+			return false
+		})
+
+		return res
+	}
+
+	require.Equal(t, []string{cert1.Serial.String()}, expiring(now, now.Add(24*time.Hour)))
+	require.Equal(t, []string{cert1.Serial.String(), cert2.Serial.String()}, expiring(now, now.Add(72*time.Hour)))
+	require.Empty(t, expiring(now.Add(72*time.Hour), now.Add(96*time.Hour)))
+
+	err := keeper.RevokeCertificate(ctx, types.CertID{
+		Owner:  owner,
+		Serial: cert1.Serial,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{cert2.Serial.String()}, expiring(now, now.Add(72*time.Hour)))
+
+	err = keeper.ExpireCertificate(ctx, types.CertID{
+		Owner:  owner,
+		Serial: cert2.Serial,
+	})
+	require.NoError(t, err)
+
+	resp, exists := keeper.GetCertificateByID(ctx, types.CertID{
+		Owner:  owner,
+		Serial: cert2.Serial,
+	})
+	require.True(t, exists)
+	testutil.CertificateRequireEqualResponse(t, cert2, resp, types.CertificateRevoked)
+	require.Empty(t, expiring(now, now.Add(72*time.Hour)))
+}
+
+func TestCertKeeperEvents(t *testing.T) {
+	ctx, keeper := setupKeeper(t)
+	owner := testutil.AccAddress(t)
+
+	cert1 := testutil.Certificate(t, owner)
+	cert2 := testutil.Certificate(t, owner)
+
+	id1 := types.CertID{Owner: owner, Serial: cert1.Serial}
+	id2 := types.CertID{Owner: owner, Serial: cert2.Serial}
+
+	require.NoError(t, keeper.CreateCertificate(ctx, owner, cert1.PEM.Cert, cert1.PEM.Pub))
+	require.NoError(t, keeper.CreateCertificate(ctx, owner, cert2.PEM.Cert, cert2.PEM.Pub))
+	require.NoError(t, keeper.RevokeCertificate(ctx, id1))
+	require.NoError(t, keeper.ExpireCertificate(ctx, id2))
+
+	// failed revoke emits nothing
+	require.Error(t, keeper.RevokeCertificate(ctx, id1))
+
+	var evs []sdkutil.ModuleEvent
+
+	for _, abciEv := range ctx.EventManager().ABCIEvents() {
+		ev, err := sdkutil.ParseEvent(sdk.StringifyEvent(abciEv))
+		require.NoError(t, err)
+
+		mev, err := ctypes.ParseEvent(ev)
+		require.NoError(t, err)
+
+		evs = append(evs, mev)
+	}
+
+	require.Equal(t, []sdkutil.ModuleEvent{
+		ctypes.NewEventCertificateCreated(id1, types.CertificateValid),
+		ctypes.NewEventCertificateCreated(id2, types.CertificateValid),
+		ctypes.NewEventCertificateRevoked(id1, types.CertificateRevoked),
+		ctypes.NewEventCertificateExpired(id2, types.CertificateRevoked),
+	}, evs)
+}
+
+func TestCertEventParseState(t *testing.T) {
+	owner := testutil.AccAddress(t)
+	id := types.CertID{Owner: owner, Serial: testutil.Certificate(t, owner).Serial}
+
+	parse := func(attrs []sdk.Attribute) (sdkutil.ModuleEvent, error) {
+		ev, err := sdkutil.ParseEvent(sdk.StringifyEvent(abci.Event(sdk.NewEvent(sdkutil.EventTypeMessage, attrs...))))
+		require.NoError(t, err)
+
+		return ctypes.ParseEvent(ev)
+	}
+
+	attrs := ctypes.NewEventCertificateRevoked(id, types.CertificateRevoked).ToSDKEvent().Attributes
+
+	var valid []sdk.Attribute
+	for _, attr := range attrs {
+		valid = append(valid, sdk.NewAttribute(string(attr.Key), string(attr.Value)))
+	}
+
+	mev, err := parse(valid)
+	require.NoError(t, err)
+	require.Equal(t, ctypes.NewEventCertificateRevoked(id, types.CertificateRevoked), mev)
+
+	for _, state := range []string{"", "unknown", types.CertificateStateInvalid.String()} {
+		invalid := make([]sdk.Attribute, 0, len(valid))
+		for _, attr := range valid {
+			if attr.Key == "state" {
+				attr.Value = state
+			}
+			invalid = append(invalid, attr)
+		}
+
+		_, err = parse(invalid)
+		require.ErrorIs(t, err, ctypes.ErrParsingState, state)
+	}
+
+	// state attribute is required
+	_, err = parse(valid[:len(valid)-1])
+	require.Error(t, err)
+}
+
 func setupKeeper(t testing.TB) (sdk.Context, keeper.Keeper) {
 	t.Helper()
 	key := sdk.NewKVStoreKey(types.StoreKey)
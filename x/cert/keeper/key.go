@@ -2,7 +2,9 @@ package keeper
 
 import (
 	"bytes"
+	"encoding/binary"
 	"math/big"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
@@ -25,6 +27,7 @@ const (
 
 var (
 	CertPrefix             = []byte{0x11}
+	CertExpiryPrefix       = []byte{0x12}
 	CertStateValidPrefix   = []byte{CertStateValidPrefixID}
 	CertStateRevokedPrefix = []byte{CertStateRevokedPrefixID}
 )
@@ -192,6 +195,66 @@ func ParseCertKey(from []byte) (types.Certificate_State, types.CertID, error) {
 	return state, res, nil
 }
 
+// CertificateExpiryKey creates an expiry index key of the format:
+// prefix_bytes | not_after (8 bytes, unix seconds) | owner_address_len (1 byte) | owner_address_bytes | serial length (1 byte) | serial_bytes
+func CertificateExpiryKey(notAfter time.Time, id types.CertID) ([]byte, error) {
+	if id.Owner.Empty() {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "owner address is empty")
+	}
+
+	addr, err := address.LengthPrefix(id.Owner.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	serial, err := serialPrefix(id.Serial.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(certExpiryPrefix(notAfter))
+	if _, err := buf.Write(addr); err != nil {
+		return nil, err
+	}
+
+	if _, err := buf.Write(serial); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func MustCertificateExpiryKey(notAfter time.Time, id types.CertID) []byte {
+	key, err := CertificateExpiryKey(notAfter, id)
+	if err != nil {
+		panic(err)
+	}
+
+	return key
+}
+
+// certExpiryPrefix returns expiry index prefix of certificates with given NotAfter.
+// NotAfter before unix epoch is indexed as epoch.
+func certExpiryPrefix(notAfter time.Time) []byte {
+	ts := notAfter.Unix()
+	if ts < 0 {
+		ts = 0
+	}
+
+	res := make([]byte, len(CertExpiryPrefix)+8)
+	copy(res, CertExpiryPrefix)
+	binary.BigEndian.PutUint64(res[len(CertExpiryPrefix):], uint64(ts))
+
+	return res
+}
+
+// certExpiryNotAfter returns NotAfter encoded in the expiry index key
+func certExpiryNotAfter(key []byte) time.Time {
+	ts := binary.BigEndian.Uint64(key[len(CertExpiryPrefix) : len(CertExpiryPrefix)+8])
+
+	return time.Unix(int64(ts), 0)
+}
+
 // CertificateKeyLegacy creates a store key of the format:
 // prefix_bytes | owner_address_len (1 byte) | owner_address_bytes | serial_bytes
 func CertificateKeyLegacy(id types.CertID) []byte {
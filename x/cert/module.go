@@ -25,6 +25,7 @@ import (
 	"github.com/akash-network/node/x/cert/client/cli"
 	"github.com/akash-network/node/x/cert/handler"
 	"github.com/akash-network/node/x/cert/keeper"
+	"github.com/akash-network/node/x/cert/query"
 	"github.com/akash-network/node/x/cert/simulation"
 )
 
@@ -133,14 +134,14 @@ func (am AppModule) Route() sdk.Route {
 	return sdk.NewRoute(types.RouterKey, handler.NewHandler(am.keeper))
 }
 
-// QuerierRoute returns the audit module's querier route name.
+// QuerierRoute returns the cert module's querier route name.
 func (am AppModule) QuerierRoute() string {
-	return ""
+	return types.ModuleName
 }
 
-// LegacyQuerierHandler returns the sdk.Querier for audit module
-func (am AppModule) LegacyQuerierHandler(_ *codec.LegacyAmino) sdk.Querier {
-	return nil
+// LegacyQuerierHandler returns the sdk.Querier for cert module
+func (am AppModule) LegacyQuerierHandler(legacyQuerierCdc *codec.LegacyAmino) sdk.Querier {
+	return query.NewQuerier(am.keeper, legacyQuerierCdc)
 }
 
 // RegisterServices registers the module's services
@@ -152,9 +153,10 @@ func (am AppModule) RegisterServices(cfg module.Configurator) {
 // BeginBlock performs no-op
 func (am AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
 
-// EndBlock returns the end blocker for the audit module. It returns no auditor
-// updates.
-func (am AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+// EndBlock returns the end blocker for the cert module. It revokes expired
+// certificates and returns no validator updates.
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	handler.EndBlocker(ctx, am.keeper)
 	return []abci.ValidatorUpdate{}
 }
 
@@ -175,7 +177,7 @@ func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.Raw
 
 // ConsensusVersion implements module.AppModule#ConsensusVersion
 func (am AppModule) ConsensusVersion() uint64 {
-	return 3
+	return 4
 }
 
 // ____________________________________________________________________________
@@ -0,0 +1,44 @@
+package query
+
+import (
+	"fmt"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+
+	types "github.com/akash-network/akash-api/go/node/cert/v1beta3"
+
+	"github.com/akash-network/node/x/cert/keeper"
+)
+
+// Client interface
+type Client interface {
+	CertificatesExpiring(keeper.QueryCertificatesExpiringRequest) (types.QueryCertificatesResponse, error)
+}
+
+// NewClient creates a client instance with provided context and key
+func NewClient(ctx sdkclient.Context, key string) Client {
+	return &client{ctx: ctx, key: key}
+}
+
+type client struct {
+	ctx sdkclient.Context
+	key string
+}
+
+func (c *client) CertificatesExpiring(req keeper.QueryCertificatesExpiringRequest) (types.QueryCertificatesResponse, error) {
+	var obj types.QueryCertificatesResponse
+
+	data, err := c.ctx.LegacyAmino.MarshalJSON(req)
+	if err != nil {
+		return obj, err
+	}
+
+	buf, _, err := c.ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", c.key, ExpiringPath), data)
+	if err != nil {
+		return obj, err
+	}
+
+	err = c.ctx.LegacyAmino.UnmarshalJSON(buf, &obj)
+
+	return obj, err
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	types "github.com/akash-network/akash-api/go/node/cert/v1beta3"
+
+	"github.com/akash-network/node/x/cert/keeper"
+)
+
+const (
+	// ExpiringPath is the query path of valid certificates expiring within a time window
+	ExpiringPath = "expiring"
+)
+
+// NewQuerier creates and returns a new cert querier instance
+func NewQuerier(k keeper.Keeper, cdc *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "empty %s query path", types.ModuleName)
+		}
+
+		switch path[0] {
+		case ExpiringPath:
+			var params keeper.QueryCertificatesExpiringRequest
+			if err := cdc.UnmarshalJSON(req.Data, &params); err != nil {
+				return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+			}
+
+			res, err := k.Querier().CertificatesExpiring(sdk.WrapSDKContext(ctx), &params)
+			if err != nil {
+				return nil, err
+			}
+
+			return marshalJSON(cdc, res)
+		}
+
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint: %s", types.ModuleName, path[0])
+	}
+}
+
+func marshalJSON(cdc *codec.LegacyAmino, obj interface{}) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, obj)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
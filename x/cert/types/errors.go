@@ -0,0 +1,12 @@
+package types
+
+import (
+	"errors"
+)
+
+var (
+	// ErrParsingSerial indicates serial attribute of an event could not be parsed
+	ErrParsingSerial = errors.New("error parsing certificate serial")
+	// ErrParsingState indicates state attribute of an event is missing or not a valid certificate state
+	ErrParsingState = errors.New("error parsing certificate state")
+)
@@ -0,0 +1,207 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ctypes "github.com/akash-network/akash-api/go/node/cert/v1beta3"
+	"github.com/akash-network/akash-api/go/sdkutil"
+)
+
+const (
+	evActionCertificateCreated = "certificate-created"
+	evActionCertificateRevoked = "certificate-revoked"
+	evActionCertificateExpired = "certificate-expired"
+	evOwnerKey                 = "owner"
+	evSerialKey                = "serial"
+	evStateKey                 = "state"
+)
+
+// EventCertificateCreated struct
+type EventCertificateCreated struct {
+	Context sdkutil.BaseModuleEvent  `json:"context"`
+	Owner   sdk.AccAddress           `json:"owner"`
+	Serial  string                   `json:"serial"`
+	State   ctypes.Certificate_State `json:"state"`
+}
+
+// NewEventCertificateCreated initializes certificate created event
+func NewEventCertificateCreated(id ctypes.CertID, state ctypes.Certificate_State) EventCertificateCreated {
+	return EventCertificateCreated{
+		Context: sdkutil.BaseModuleEvent{
+			Module: ctypes.ModuleName,
+			Action: evActionCertificateCreated,
+		},
+		Owner:  sdk.AccAddress(id.Owner.Bytes()),
+		Serial: id.Serial.String(),
+		State:  state,
+	}
+}
+
+// ToSDKEvent method creates new sdk event for EventCertificateCreated struct
+func (ev EventCertificateCreated) ToSDKEvent() sdk.Event {
+	return sdk.NewEvent(sdkutil.EventTypeMessage,
+		append([]sdk.Attribute{
+			sdk.NewAttribute(sdk.AttributeKeyModule, ctypes.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeyAction, evActionCertificateCreated),
+		}, CertificateEVAttributes(ev.Owner, ev.Serial, ev.State)...)...,
+	)
+}
+
+// EventCertificateRevoked struct
+type EventCertificateRevoked struct {
+	Context sdkutil.BaseModuleEvent  `json:"context"`
+	Owner   sdk.AccAddress           `json:"owner"`
+	Serial  string                   `json:"serial"`
+	State   ctypes.Certificate_State `json:"state"`
+}
+
+// NewEventCertificateRevoked initializes certificate revoked event
+func NewEventCertificateRevoked(id ctypes.CertID, state ctypes.Certificate_State) EventCertificateRevoked {
+	return EventCertificateRevoked{
+		Context: sdkutil.BaseModuleEvent{
+			Module: ctypes.ModuleName,
+			Action: evActionCertificateRevoked,
+		},
+		Owner:  sdk.AccAddress(id.Owner.Bytes()),
+		Serial: id.Serial.String(),
+		State:  state,
+	}
+}
+
+// ToSDKEvent method creates new sdk event for EventCertificateRevoked struct
+func (ev EventCertificateRevoked) ToSDKEvent() sdk.Event {
+	return sdk.NewEvent(sdkutil.EventTypeMessage,
+		append([]sdk.Attribute{
+			sdk.NewAttribute(sdk.AttributeKeyModule, ctypes.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeyAction, evActionCertificateRevoked),
+		}, CertificateEVAttributes(ev.Owner, ev.Serial, ev.State)...)...,
+	)
+}
+
+// EventCertificateExpired struct
+type EventCertificateExpired struct {
+	Context sdkutil.BaseModuleEvent  `json:"context"`
+	Owner   sdk.AccAddress           `json:"owner"`
+	Serial  string                   `json:"serial"`
+	State   ctypes.Certificate_State `json:"state"`
+}
+
+// NewEventCertificateExpired initializes certificate expired event
+func NewEventCertificateExpired(id ctypes.CertID, state ctypes.Certificate_State) EventCertificateExpired {
+	return EventCertificateExpired{
+		Context: sdkutil.BaseModuleEvent{
+			Module: ctypes.ModuleName,
+			Action: evActionCertificateExpired,
+		},
+		Owner:  sdk.AccAddress(id.Owner.Bytes()),
+		Serial: id.Serial.String(),
+		State:  state,
+	}
+}
+
+// ToSDKEvent method creates new sdk event for EventCertificateExpired struct
+func (ev EventCertificateExpired) ToSDKEvent() sdk.Event {
+	return sdk.NewEvent(sdkutil.EventTypeMessage,
+		append([]sdk.Attribute{
+			sdk.NewAttribute(sdk.AttributeKeyModule, ctypes.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeyAction, evActionCertificateExpired),
+		}, CertificateEVAttributes(ev.Owner, ev.Serial, ev.State)...)...,
+	)
+}
+
+// CertificateEVAttributes returns event attributes for given certificate owner, serial and state
+func CertificateEVAttributes(owner sdk.AccAddress, serial string, state ctypes.Certificate_State) []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(evOwnerKey, owner.String()),
+		sdk.NewAttribute(evSerialKey, serial),
+		sdk.NewAttribute(evStateKey, state.String()),
+	}
+}
+
+// ParseEVCertID returns certificate id for given event attributes
+func ParseEVCertID(attrs []sdk.Attribute) (ctypes.CertID, error) {
+	owner, err := sdkutil.GetAccAddress(attrs, evOwnerKey)
+	if err != nil {
+		return ctypes.CertID{}, err
+	}
+
+	val, err := sdkutil.GetString(attrs, evSerialKey)
+	if err != nil {
+		return ctypes.CertID{}, err
+	}
+
+	serial, ok := new(big.Int).SetString(val, 10)
+	if !ok {
+		return ctypes.CertID{}, ErrParsingSerial
+	}
+
+	return ctypes.CertID{
+		Owner:  owner,
+		Serial: *serial,
+	}, nil
+}
+
+// ParseEVCertState returns certificate state for given event attributes
+func ParseEVCertState(attrs []sdk.Attribute) (ctypes.Certificate_State, error) {
+	val, err := sdkutil.GetString(attrs, evStateKey)
+	if err != nil {
+		return ctypes.CertificateStateInvalid, err
+	}
+
+	state, ok := ctypes.Certificate_State_value[val]
+	if !ok || ctypes.Certificate_State(state) == ctypes.CertificateStateInvalid {
+		return ctypes.CertificateStateInvalid, fmt.Errorf("%w: %q", ErrParsingState, val)
+	}
+
+	return ctypes.Certificate_State(state), nil
+}
+
+// parseEVCertIDState returns certificate id and state for given event attributes
+func parseEVCertIDState(attrs []sdk.Attribute) (ctypes.CertID, ctypes.Certificate_State, error) {
+	id, err := ParseEVCertID(attrs)
+	if err != nil {
+		return ctypes.CertID{}, ctypes.CertificateStateInvalid, err
+	}
+
+	state, err := ParseEVCertState(attrs)
+	if err != nil {
+		return ctypes.CertID{}, ctypes.CertificateStateInvalid, err
+	}
+
+	return id, state, nil
+}
+
+// ParseEvent parses event and returns details of event and error if occurred
+func ParseEvent(ev sdkutil.Event) (sdkutil.ModuleEvent, error) {
+	if ev.Type != sdkutil.EventTypeMessage {
+		return nil, sdkutil.ErrUnknownType
+	}
+	if ev.Module != ctypes.ModuleName {
+		return nil, sdkutil.ErrUnknownModule
+	}
+	switch ev.Action {
+	case evActionCertificateCreated:
+		id, state, err := parseEVCertIDState(ev.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return NewEventCertificateCreated(id, state), nil
+	case evActionCertificateRevoked:
+		id, state, err := parseEVCertIDState(ev.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return NewEventCertificateRevoked(id, state), nil
+	case evActionCertificateExpired:
+		id, state, err := parseEVCertIDState(ev.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return NewEventCertificateExpired(id, state), nil
+	default:
+		return nil, sdkutil.ErrUnknownAction
+	}
+}
@@ -9,11 +9,15 @@ import (
 	"github.com/spf13/pflag"
 
 	types "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+
+	"github.com/akash-network/node/sdl"
+	"github.com/akash-network/node/util/attributes"
 )
 
 const (
 	FlagDepositorAccount = "depositor-account"
 	FlagExpiration       = "expiration"
+	FlagAttributesMode   = "attributes-mode"
 )
 
 var (
@@ -210,3 +214,30 @@ func DepositorFromFlags(flags *pflag.FlagSet, owner string) (string, error) {
 	_, err = sdk.AccAddressFromBech32(depositorAcc)
 	return depositorAcc, err
 }
+
+// AddAttributesModeFlag adds the `--attributes-mode` flag
+func AddAttributesModeFlag(flags *pflag.FlagSet) {
+	flags.String(FlagAttributesMode, attributes.ModeWarn.String(),
+		"placement attributes check against the well-known provider attribute registry (strict|warn)")
+}
+
+// SDLReadOptionsFromFlags returns SDL read options for the attributes mode set in flags.
+// Warnings are printed to command's stderr
+func SDLReadOptionsFromFlags(cmd *cobra.Command) ([]sdl.ReadOption, error) {
+	val, err := cmd.Flags().GetString(FlagAttributesMode)
+	if err != nil {
+		return nil, err
+	}
+
+	mode, err := attributes.ParseMode(val)
+	if err != nil {
+		return nil, err
+	}
+
+	return []sdl.ReadOption{
+		sdl.WithAttributesMode(mode),
+		sdl.WithAttributesWarnings(func(warning error) {
+			cmd.PrintErrln("warning:", warning)
+		}),
+	}, nil
+}
@@ -83,7 +83,12 @@ func cmdCreate(key string) *cobra.Command {
 				return err
 			}
 
-			sdlManifest, err := sdl.ReadFile(args[0])
+			sdlOpts, err := SDLReadOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			sdlManifest, err := sdl.ReadFile(args[0], sdlOpts...)
 			if err != nil {
 				return err
 			}
@@ -157,6 +162,7 @@ func cmdCreate(key string) *cobra.Command {
 	flags.AddTxFlagsToCmd(cmd)
 	AddDeploymentIDFlags(cmd.Flags())
 	AddDepositorFlag(cmd.Flags())
+	AddAttributesModeFlag(cmd.Flags())
 	common.AddDepositFlags(cmd.Flags())
 
 	return cmd
@@ -294,7 +300,12 @@ func cmdUpdate(key string) *cobra.Command {
 				return err
 			}
 
-			sdlManifest, err := sdl.ReadFile(args[0])
+			sdlOpts, err := SDLReadOptionsFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			sdlManifest, err := sdl.ReadFile(args[0], sdlOpts...)
 			if err != nil {
 				return err
 			}
@@ -347,6 +358,7 @@ func cmdUpdate(key string) *cobra.Command {
 
 	flags.AddTxFlagsToCmd(cmd)
 	AddDeploymentIDFlags(cmd.Flags())
+	AddAttributesModeFlag(cmd.Flags())
 
 	return cmd
 }
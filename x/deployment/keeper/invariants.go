@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+)
+
+const (
+	invariantGroupState = "group-state"
+	invariantEscrow     = "escrow"
+)
+
+// RegisterInvariants registers the deployment module invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k IKeeper, ek EscrowKeeper) {
+	ir.RegisterRoute(types.ModuleName, invariantGroupState, GroupStateInvariant(k))
+	ir.RegisterRoute(types.ModuleName, invariantEscrow, EscrowInvariant(k, ek))
+}
+
+// AllInvariants runs all invariants of the deployment module
+func AllInvariants(k IKeeper, ek EscrowKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		if res, stop := GroupStateInvariant(k)(ctx); stop {
+			return res, stop
+		}
+
+		return EscrowInvariant(k, ek)(ctx)
+	}
+}
+
+// GroupStateInvariant checks that every deployment has groups and all groups
+// of a closed deployment are closed
+func GroupStateInvariant(k IKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		k.WithDeployments(ctx, func(deployment types.Deployment) bool {
+			groups := k.GetGroups(ctx, deployment.ID())
+			if len(groups) == 0 {
+				count++
+				msg += fmt.Sprintf("\tdeployment %s has no groups\n", deployment.ID())
+			}
+
+			if deployment.State != types.DeploymentClosed {
+				return false
+			}
+
+			for _, group := range groups {
+				switch group.State {
+				case types.GroupClosed, types.GroupInsufficientFunds:
+				default:
+					count++
+					msg += fmt.Sprintf("\tclosed deployment %s has group %s in state %s\n", deployment.ID(), group.ID(), group.State)
+				}
+			}
+
+			return false
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, invariantGroupState,
+			fmt.Sprintf("amount of inconsistent groups found %d\n%s", count, msg)), broken
+	}
+}
+
+// EscrowInvariant checks that every active deployment has an open escrow account
+// and escrow account of every closed deployment is not open
+func EscrowInvariant(k IKeeper, ek EscrowKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		k.WithDeployments(ctx, func(deployment types.Deployment) bool {
+			account, err := ek.GetAccount(ctx, types.EscrowAccountForDeployment(deployment.ID()))
+			if err != nil {
+				count++
+				msg += fmt.Sprintf("\tdeployment %s has no escrow account\n", deployment.ID())
+				return false
+			}
+
+			switch {
+			case deployment.State == types.DeploymentActive && account.State != etypes.AccountOpen:
+				count++
+				msg += fmt.Sprintf("\tactive deployment %s has escrow account in state %s\n", deployment.ID(), account.State)
+			case deployment.State == types.DeploymentClosed && account.State == etypes.AccountOpen:
+				count++
+				msg += fmt.Sprintf("\tclosed deployment %s has open escrow account\n", deployment.ID())
+			}
+
+			return false
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, invariantEscrow,
+			fmt.Sprintf("amount of inconsistent escrow accounts found %d\n%s", count, msg)), broken
+	}
+}
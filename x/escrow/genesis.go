@@ -13,8 +13,74 @@ import (
 	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
 )
 
+// GenesisState of the escrow module. Escrow genesis of the API can not be extended,
+// params are kept next to its fields.
+type GenesisState struct {
+	types.GenesisState
+	Params keeper.Params
+}
+
+// genesisParamsKey is the JSON field of params in escrow genesis
+const genesisParamsKey = "params"
+
+// MarshalGenesis encodes genesis state as JSON. Fields of the API genesis are encoded
+// with the codec to keep its layout.
+func MarshalGenesis(cdc codec.JSONCodec, data *GenesisState) (json.RawMessage, error) {
+	bz, err := cdc.MarshalJSON(&data.GenesisState)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return nil, err
+	}
+
+	if fields[genesisParamsKey], err = json.Marshal(data.Params); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalGenesis decodes genesis state from JSON. Genesis of the API is accepted as is,
+// params missing from it fall back to defaults.
+func UnmarshalGenesis(cdc codec.JSONCodec, bz json.RawMessage) (*GenesisState, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return nil, err
+	}
+
+	data := &GenesisState{
+		Params: keeper.DefaultParams(),
+	}
+
+	if raw, exists := fields[genesisParamsKey]; exists {
+		if err := json.Unmarshal(raw, &data.Params); err != nil {
+			return nil, err
+		}
+
+		delete(fields, genesisParamsKey)
+	}
+
+	bz, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cdc.UnmarshalJSON(bz, &data.GenesisState); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
 // ValidateGenesis does validation check of the Genesis and returns error in case of failure
-func ValidateGenesis(data *types.GenesisState) error {
+func ValidateGenesis(data *GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
 	amap := make(map[types.AccountID]types.Account, len(data.Accounts))
 	pmap := make(map[types.AccountID][]types.FractionalPayment, len(data.Payments))
 
@@ -65,7 +131,10 @@ func ValidateGenesis(data *types.GenesisState) error {
 }
 
 // InitGenesis initiate genesis state and return updated validator details
-func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data *types.GenesisState) []abci.ValidatorUpdate {
+func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data *GenesisState) []abci.ValidatorUpdate {
+	// params are set first, saved accounts are scheduled for settlement according to them
+	keeper.SetParams(ctx, data.Params)
+
 	for idx := range data.Accounts {
 		keeper.SaveAccount(ctx, data.Accounts[idx])
 	}
@@ -77,8 +146,10 @@ func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data *types.GenesisState
 }
 
 // ExportGenesis returns genesis state as raw bytes for the provider module
-func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
-	state := &types.GenesisState{}
+func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *GenesisState {
+	state := &GenesisState{
+		Params: k.GetParams(ctx),
+	}
 
 	k.WithAccounts(ctx, func(obj types.Account) bool {
 		state.Accounts = append(state.Accounts, obj)
@@ -95,18 +166,25 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper) *types.GenesisState {
 
 // DefaultGenesisState returns default genesis state as raw bytes for the provider
 // module.
-func DefaultGenesisState() *types.GenesisState {
-	return &types.GenesisState{}
+func DefaultGenesisState() *GenesisState {
+	return &GenesisState{
+		Params: keeper.DefaultParams(),
+	}
 }
 
 // GetGenesisStateFromAppState returns x/escrow GenesisState given raw application
-// genesis state.
-func GetGenesisStateFromAppState(cdc codec.JSONCodec, appState map[string]json.RawMessage) *types.GenesisState {
-	var genesisState types.GenesisState
+// genesis state. It panics if module genesis can not be decoded.
+func GetGenesisStateFromAppState(cdc codec.JSONCodec, appState map[string]json.RawMessage) *GenesisState {
+	if appState[ModuleName] == nil {
+		return &GenesisState{
+			Params: keeper.DefaultParams(),
+		}
+	}
 
-	if appState[ModuleName] != nil {
-		cdc.MustUnmarshalJSON(appState[ModuleName], &genesisState)
+	genesisState, err := UnmarshalGenesis(cdc, appState[ModuleName])
+	if err != nil {
+		panic(err)
 	}
 
-	return &genesisState
+	return genesisState
 }
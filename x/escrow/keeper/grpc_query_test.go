@@ -0,0 +1,295 @@
+package keeper_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+
+	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+
+	"github.com/akash-network/node/app"
+	"github.com/akash-network/node/testutil"
+	"github.com/akash-network/node/testutil/state"
+	"github.com/akash-network/node/x/escrow/keeper"
+)
+
+type grpcTestSuite struct {
+	t      *testing.T
+	app    *app.AkashApp
+	ctx    sdk.Context
+	keeper keeper.Keeper
+
+	queryClient types.QueryClient
+}
+
+func setupTest(t *testing.T) *grpcTestSuite {
+	ssuite := state.SetupTestSuite(t)
+	suite := &grpcTestSuite{
+		t:      t,
+		app:    ssuite.App(),
+		ctx:    ssuite.Context(),
+		keeper: ssuite.EscrowKeeper(),
+	}
+
+	queryHelper := baseapp.NewQueryServerTestHelper(suite.ctx, suite.app.InterfaceRegistry())
+	types.RegisterQueryServer(queryHelper, keeper.NewQuerier(suite.keeper))
+	suite.queryClient = types.NewQueryClient(queryHelper)
+
+	return suite
+}
+
+func (suite *grpcTestSuite) createAccount(owner sdk.AccAddress) types.AccountID {
+	suite.t.Helper()
+
+	id := genAccountID(suite.t)
+	err := suite.keeper.AccountCreate(suite.ctx, id, owner, owner, testutil.AkashCoin(suite.t, 1000))
+	require.NoError(suite.t, err)
+
+	return id
+}
+
+func TestGRPCQueryAccounts(t *testing.T) {
+	suite := setupTest(t)
+
+	owner := testutil.AccAddress(t)
+
+	aid1 := suite.createAccount(owner)
+	aid2 := suite.createAccount(owner)
+	aid3 := suite.createAccount(testutil.AccAddress(t))
+
+	require.NoError(t, suite.keeper.AccountClose(suite.ctx, aid3))
+
+	var req *types.QueryAccountsRequest
+
+	testCases := []struct {
+		msg      string
+		malleate func()
+		expPass  bool
+		expLen   int
+	}{
+		{
+			"query accounts without any filters and pagination",
+			func() {
+				req = &types.QueryAccountsRequest{}
+			},
+			true,
+			3,
+		},
+		{
+			"query accounts with scope and xid",
+			func() {
+				req = &types.QueryAccountsRequest{Scope: aid1.Scope, Xid: aid1.XID}
+			},
+			true,
+			1,
+		},
+		{
+			"query accounts with owner",
+			func() {
+				req = &types.QueryAccountsRequest{Owner: owner.String()}
+			},
+			true,
+			2,
+		},
+		{
+			"query accounts with state",
+			func() {
+				req = &types.QueryAccountsRequest{State: types.AccountClosed.String()}
+			},
+			true,
+			1,
+		},
+		{
+			"query accounts with owner and non matching state",
+			func() {
+				req = &types.QueryAccountsRequest{Owner: owner.String(), State: types.AccountClosed.String()}
+			},
+			true,
+			0,
+		},
+		{
+			"query accounts with pagination",
+			func() {
+				req = &types.QueryAccountsRequest{Pagination: &sdkquery.PageRequest{Limit: 1}}
+			},
+			true,
+			1,
+		},
+		{
+			"query accounts with non existent xid",
+			func() {
+				req = &types.QueryAccountsRequest{Scope: aid2.Scope, Xid: aid2.XID + "0"}
+			},
+			true,
+			0,
+		},
+		{
+			"query accounts with xid but no scope",
+			func() {
+				req = &types.QueryAccountsRequest{Xid: aid2.XID}
+			},
+			false,
+			0,
+		},
+		{
+			"query accounts with invalid state",
+			func() {
+				req = &types.QueryAccountsRequest{State: "bogus"}
+			},
+			false,
+			0,
+		},
+		{
+			"query accounts with invalid owner",
+			func() {
+				req = &types.QueryAccountsRequest{Owner: "bogus"}
+			},
+			false,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Case %s", tc.msg), func(t *testing.T) {
+			tc.malleate()
+			ctx := sdk.WrapSDKContext(suite.ctx)
+
+			res, err := suite.queryClient.Accounts(ctx, req)
+
+			if tc.expPass {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Len(t, res.Accounts, tc.expLen)
+			} else {
+				require.Error(t, err)
+				require.Nil(t, res)
+			}
+		})
+	}
+}
+
+func TestGRPCQueryPayments(t *testing.T) {
+	suite := setupTest(t)
+
+	aid1 := suite.createAccount(testutil.AccAddress(t))
+	aid2 := suite.createAccount(testutil.AccAddress(t))
+
+	provider := testutil.AccAddress(t)
+	rate := sdk.NewDecCoinFromCoin(testutil.AkashCoin(t, 1))
+
+	pid1 := testutil.Name(t, "payment")
+	pid2 := testutil.Name(t, "payment")
+
+	require.NoError(t, suite.keeper.PaymentCreate(suite.ctx, aid1, pid1, provider, rate))
+	require.NoError(t, suite.keeper.PaymentCreate(suite.ctx, aid1, pid2, testutil.AccAddress(t), rate))
+	require.NoError(t, suite.keeper.PaymentCreate(suite.ctx, aid2, pid1, provider, rate))
+
+	// payments are only persisted as closed once there are earnings to withdraw
+	suite.ctx = suite.ctx.WithBlockHeight(suite.ctx.BlockHeight() + 10)
+	require.NoError(t, suite.keeper.PaymentClose(suite.ctx, aid2, pid1))
+
+	var req *types.QueryPaymentsRequest
+
+	testCases := []struct {
+		msg      string
+		malleate func()
+		expPass  bool
+		expLen   int
+	}{
+		{
+			"query payments without any filters and pagination",
+			func() {
+				req = &types.QueryPaymentsRequest{}
+			},
+			true,
+			3,
+		},
+		{
+			"query payments with scope and xid",
+			func() {
+				req = &types.QueryPaymentsRequest{Scope: aid1.Scope, Xid: aid1.XID}
+			},
+			true,
+			2,
+		},
+		{
+			"query payments with scope, xid and id",
+			func() {
+				req = &types.QueryPaymentsRequest{Scope: aid1.Scope, Xid: aid1.XID, Id: pid1}
+			},
+			true,
+			1,
+		},
+		{
+			"query payments with id",
+			func() {
+				req = &types.QueryPaymentsRequest{Id: pid1}
+			},
+			true,
+			2,
+		},
+		{
+			"query payments with owner",
+			func() {
+				req = &types.QueryPaymentsRequest{Owner: provider.String()}
+			},
+			true,
+			2,
+		},
+		{
+			"query payments with owner and state",
+			func() {
+				req = &types.QueryPaymentsRequest{Owner: provider.String(), State: types.PaymentOpen.String()}
+			},
+			true,
+			1,
+		},
+		{
+			"query payments with pagination",
+			func() {
+				req = &types.QueryPaymentsRequest{Pagination: &sdkquery.PageRequest{Limit: 2}}
+			},
+			true,
+			2,
+		},
+		{
+			"query payments with invalid state",
+			func() {
+				req = &types.QueryPaymentsRequest{State: "bogus"}
+			},
+			false,
+			0,
+		},
+		{
+			"query payments with invalid owner",
+			func() {
+				req = &types.QueryPaymentsRequest{Owner: "bogus"}
+			},
+			false,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(fmt.Sprintf("Case %s", tc.msg), func(t *testing.T) {
+			tc.malleate()
+			ctx := sdk.WrapSDKContext(suite.ctx)
+
+			res, err := suite.queryClient.Payments(ctx, req)
+
+			if tc.expPass {
+				require.NoError(t, err)
+				require.NotNil(t, res)
+				require.Len(t, res.Payments, tc.expLen)
+			} else {
+				require.Error(t, err)
+				require.Nil(t, res)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+)
+
+const (
+	invariantModuleBalance      = "module-balance"
+	invariantNonNegativeBalance = "nonnegative-balance"
+	invariantPaymentState       = "payment-state"
+)
+
+// RegisterInvariants registers the escrow module invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper, bk BankKeeper) {
+	ir.RegisterRoute(types.ModuleName, invariantModuleBalance, ModuleBalanceInvariant(k, bk))
+	ir.RegisterRoute(types.ModuleName, invariantNonNegativeBalance, NonNegativeBalanceInvariant(k))
+	ir.RegisterRoute(types.ModuleName, invariantPaymentState, PaymentStateInvariant(k))
+}
+
+// AllInvariants runs all invariants of the escrow module
+func AllInvariants(k Keeper, bk BankKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		if res, stop := NonNegativeBalanceInvariant(k)(ctx); stop {
+			return res, stop
+		}
+
+		if res, stop := PaymentStateInvariant(k)(ctx); stop {
+			return res, stop
+		}
+
+		return ModuleBalanceInvariant(k, bk)(ctx)
+	}
+}
+
+// ModuleBalanceInvariant checks that the escrow module account holds exactly the sum of
+// balances and funds of all escrow accounts plus the unwithdrawn balances of all payments.
+// Amounts tracked by escrow are decimal while the module account holds integer coins, so
+// the sum is truncated before comparing.
+func ModuleBalanceInvariant(k Keeper, bk BankKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		expected := sdk.DecCoins{}
+
+		k.WithAccounts(ctx, func(acc types.Account) bool {
+			expected = expected.Add(acc.Balance).Add(acc.Funds)
+			return false
+		})
+
+		k.WithPayments(ctx, func(pmnt types.FractionalPayment) bool {
+			expected = expected.Add(pmnt.Balance)
+			return false
+		})
+
+		truncated, _ := expected.TruncateDecimal()
+		actual := bk.GetAllBalances(ctx, authtypes.NewModuleAddress(types.ModuleName))
+
+		broken := !truncated.IsEqual(actual)
+
+		return sdk.FormatInvariant(types.ModuleName, invariantModuleBalance,
+			fmt.Sprintf(
+				"\tsum of escrow balances: %v\n"+
+					"\tmodule account balance: %v\n",
+				expected, actual)), broken
+	}
+}
+
+// NonNegativeBalanceInvariant checks that no escrow account or payment has a negative balance
+func NonNegativeBalanceInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		k.WithAccounts(ctx, func(acc types.Account) bool {
+			if acc.Balance.IsNegative() || acc.Funds.IsNegative() {
+				count++
+				msg += fmt.Sprintf("\taccount %s has a negative balance of %s and funds of %s\n", acc.ID, acc.Balance, acc.Funds)
+			}
+
+			return false
+		})
+
+		k.WithPayments(ctx, func(pmnt types.FractionalPayment) bool {
+			if pmnt.Balance.IsNegative() {
+				count++
+				msg += fmt.Sprintf("\tpayment %s/%s has a negative balance of %s\n", pmnt.AccountID, pmnt.PaymentID, pmnt.Balance)
+			}
+
+			return false
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, invariantNonNegativeBalance,
+			fmt.Sprintf("amount of negative balances found %d\n%s", count, msg)), broken
+	}
+}
+
+// PaymentStateInvariant checks that every open payment belongs to an open account
+func PaymentStateInvariant(k Keeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		k.WithPayments(ctx, func(pmnt types.FractionalPayment) bool {
+			if pmnt.State != types.PaymentOpen {
+				return false
+			}
+
+			acc, err := k.GetAccount(ctx, pmnt.AccountID)
+			if err != nil || acc.State != types.AccountOpen {
+				count++
+				msg += fmt.Sprintf("\topen payment %s/%s has no open account\n", pmnt.AccountID, pmnt.PaymentID)
+			}
+
+			return false
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, invariantPaymentState,
+			fmt.Sprintf("amount of open payments of not open accounts found %d\n%s", count, msg)), broken
+	}
+}
@@ -0,0 +1,66 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+
+	"github.com/akash-network/node/testutil"
+	"github.com/akash-network/node/x/escrow/keeper"
+)
+
+func Test_Invariants(t *testing.T) {
+	ctx, ekeeper, bkeeper := setupKeeper(t)
+	aid := genAccountID(t)
+	aowner := testutil.AccAddress(t)
+
+	amt := testutil.AkashCoin(t, 1000)
+	pid := testutil.Name(t, "payment")
+	powner := testutil.AccAddress(t)
+	rate := testutil.AkashCoin(t, 3)
+
+	moduleAddr := authtypes.NewModuleAddress(types.ModuleName)
+
+	bkeeper.
+		On("SendCoinsFromAccountToModule", mock.Anything, aowner, types.ModuleName, sdk.NewCoins(amt)).
+		Return(nil)
+	require.NoError(t, ekeeper.AccountCreate(ctx, aid, aowner, aowner, amt))
+	require.NoError(t, ekeeper.PaymentCreate(ctx, aid, pid, powner, sdk.NewDecCoinFromCoin(rate)))
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 10)
+	_, err := ekeeper.AccountSettle(ctx, aid)
+	require.NoError(t, err)
+
+	balances := bkeeper.On("GetAllBalances", mock.Anything, moduleAddr).Return(sdk.NewCoins(amt))
+
+	_, broken := keeper.ModuleBalanceInvariant(ekeeper, bkeeper)(ctx)
+	require.False(t, broken)
+
+	_, broken = keeper.NonNegativeBalanceInvariant(ekeeper)(ctx)
+	require.False(t, broken)
+
+	_, broken = keeper.AllInvariants(ekeeper, bkeeper)(ctx)
+	require.False(t, broken)
+
+	// module account holding less than escrow accounts owe breaks the invariant
+	balances.Unset()
+	bkeeper.On("GetAllBalances", mock.Anything, moduleAddr).Return(sdk.NewCoins(amt.SubAmount(sdk.OneInt())))
+
+	_, broken = keeper.ModuleBalanceInvariant(ekeeper, bkeeper)(ctx)
+	require.True(t, broken)
+
+	// negative payment balance breaks the invariant
+	payment, err := ekeeper.GetPayment(ctx, aid, pid)
+	require.NoError(t, err)
+
+	payment.Balance = sdk.DecCoin{Denom: payment.Balance.Denom, Amount: sdk.NewDec(-1)}
+	ekeeper.SavePayment(ctx, payment)
+
+	_, broken = keeper.NonNegativeBalanceInvariant(ekeeper)(ctx)
+	require.True(t, broken)
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+
+	etypes "github.com/akash-network/node/x/escrow/types"
 )
 
 type AccountHook func(sdk.Context, types.Account)
@@ -31,12 +34,21 @@ type Keeper interface {
 	WithPayments(sdk.Context, func(types.FractionalPayment) bool)
 	SaveAccount(sdk.Context, types.Account)
 	SavePayment(sdk.Context, types.FractionalPayment)
+	SettleDueAccounts(sdk.Context) uint32
+	CloseOrphanedPayments(sdk.Context) (uint32, error)
+	GetParams(sdk.Context) Params
+	SetParams(sdk.Context, Params)
 }
 
-func NewKeeper(cdc codec.BinaryCodec, skey sdk.StoreKey, bkeeper BankKeeper, tkeeper TakeKeeper, dkeeper DistrKeeper, akeeper AuthzKeeper) Keeper {
+func NewKeeper(cdc codec.BinaryCodec, skey sdk.StoreKey, pspace paramtypes.Subspace, bkeeper BankKeeper, tkeeper TakeKeeper, dkeeper DistrKeeper, akeeper AuthzKeeper) Keeper {
+	if !pspace.HasKeyTable() {
+		pspace = pspace.WithKeyTable(ParamKeyTable())
+	}
+
 	return &keeper{
 		cdc:         cdc,
 		skey:        skey,
+		pspace:      pspace,
 		bkeeper:     bkeeper,
 		tkeeper:     tkeeper,
 		dkeeper:     dkeeper,
@@ -47,6 +59,7 @@ func NewKeeper(cdc codec.BinaryCodec, skey sdk.StoreKey, bkeeper BankKeeper, tke
 type keeper struct {
 	cdc         codec.BinaryCodec
 	skey        sdk.StoreKey
+	pspace      paramtypes.Subspace
 	bkeeper     BankKeeper
 	tkeeper     TakeKeeper
 	dkeeper     DistrKeeper
@@ -67,6 +80,18 @@ func (k *keeper) StoreKey() sdk.StoreKey {
 	return k.skey
 }
 
+// GetParams returns the total set of escrow parameters.
+func (k *keeper) GetParams(ctx sdk.Context) Params {
+	params := DefaultParams()
+	k.pspace.GetParamSetIfExists(ctx, &params)
+	return params
+}
+
+// SetParams sets the escrow parameters to the paramspace.
+func (k *keeper) SetParams(ctx sdk.Context, params Params) {
+	k.pspace.SetParamSet(ctx, &params)
+}
+
 func (k *keeper) AccountCreate(ctx sdk.Context, id types.AccountID, owner, depositor sdk.AccAddress, deposit sdk.Coin) error {
 	store := ctx.KVStore(k.skey)
 	key := accountKey(id)
@@ -96,6 +121,8 @@ func (k *keeper) AccountCreate(ctx sdk.Context, id types.AccountID, owner, depos
 
 	store.Set(key, k.cdc.MustMarshal(obj))
 
+	k.scheduleSettlement(ctx, id)
+
 	return nil
 }
 
@@ -166,13 +193,20 @@ func (k *keeper) AccountDeposit(ctx sdk.Context, id types.AccountID, depositor s
 
 	store.Set(key, k.cdc.MustMarshal(&obj))
 
+	k.scheduleSettlement(ctx, id)
+
 	return nil
 }
 
 func (k *keeper) AccountSettle(ctx sdk.Context, id types.AccountID) (bool, error) {
 	_, _, od, err := k.doAccountSettle(ctx, id)
+	if err != nil {
+		return od, err
+	}
 
-	return od, err
+	k.scheduleSettlement(ctx, id)
+
+	return od, nil
 }
 
 func (k *keeper) AccountClose(ctx sdk.Context, id types.AccountID) error {
@@ -183,9 +217,14 @@ func (k *keeper) AccountClose(ctx sdk.Context, id types.AccountID) error {
 	}
 
 	if od {
+		k.unscheduleSettlement(ctx, id)
 		return nil
 	}
 
+	// account settled earlier within the same block is not settled again
+	// and its payments are not returned, load them to close them along with the account
+	payments = k.accountOpenPayments(ctx, id)
+
 	account.State = types.AccountClosed
 	if err := k.accountWithdraw(ctx, &account); err != nil {
 		return err
@@ -198,6 +237,12 @@ func (k *keeper) AccountClose(ctx sdk.Context, id types.AccountID) error {
 		}
 	}
 
+	k.unscheduleSettlement(ctx, id)
+
+	ctx.EventManager().EmitEvent(
+		etypes.NewEventAccountClosed(id).ToSDKEvent(),
+	)
+
 	for _, hook := range k.hooks.onAccountClosed {
 		hook(ctx, account)
 	}
@@ -248,6 +293,8 @@ func (k *keeper) PaymentCreate(ctx sdk.Context, id types.AccountID, pid string,
 
 	store.Set(key, k.cdc.MustMarshal(obj))
 
+	k.scheduleSettlement(ctx, id)
+
 	return nil
 }
 
@@ -308,6 +355,8 @@ func (k *keeper) PaymentClose(ctx sdk.Context, id types.AccountID, pid string) e
 		return err
 	}
 
+	k.scheduleSettlement(ctx, id)
+
 	for _, hook := range k.hooks.onPaymentClosed {
 		hook(ctx, payment)
 	}
@@ -315,6 +364,43 @@ func (k *keeper) PaymentClose(ctx sdk.Context, id types.AccountID, pid string) e
 	return nil
 }
 
+// CloseOrphanedPayments closes and withdraws open payments of accounts which are no longer open.
+// Such payments are left behind by accounts closed within the same block they have been settled in.
+// It returns number of closed payments.
+func (k *keeper) CloseOrphanedPayments(ctx sdk.Context) (uint32, error) {
+	var payments []types.FractionalPayment
+
+	k.WithPayments(ctx, func(payment types.FractionalPayment) bool {
+		if payment.State != types.PaymentOpen {
+			return false
+		}
+
+		account, err := k.GetAccount(ctx, payment.AccountID)
+		if err == nil && account.State == types.AccountOpen {
+			return false
+		}
+
+		payments = append(payments, payment)
+
+		return false
+	})
+
+	for idx := range payments {
+		payments[idx].State = types.PaymentClosed
+		if err := k.paymentWithdraw(ctx, &payments[idx]); err != nil {
+			return 0, err
+		}
+	}
+
+	for _, hook := range k.hooks.onPaymentClosed {
+		for idx := range payments {
+			hook(ctx, payments[idx])
+		}
+	}
+
+	return uint32(len(payments)), nil
+}
+
 func (k *keeper) AddOnAccountClosedHook(hook AccountHook) Keeper {
 	k.hooks.onAccountClosed = append(k.hooks.onAccountClosed, hook)
 	return k
@@ -361,10 +447,12 @@ func (k *keeper) GetPayment(ctx sdk.Context, id types.AccountID, pid string) (ty
 
 func (k *keeper) SaveAccount(ctx sdk.Context, obj types.Account) {
 	k.saveAccount(ctx, &obj)
+	k.scheduleSettlement(ctx, obj.ID)
 }
 
 func (k *keeper) SavePayment(ctx sdk.Context, obj types.FractionalPayment) {
 	k.savePayment(ctx, &obj)
+	k.scheduleSettlement(ctx, obj.AccountID)
 }
 
 func (k *keeper) WithAccounts(ctx sdk.Context, fn func(types.Account) bool) {
@@ -489,6 +577,10 @@ func (k *keeper) doAccountSettle(ctx sdk.Context, id types.AccountID) (types.Acc
 		}
 	}
 
+	ctx.EventManager().EmitEvent(
+		etypes.NewEventAccountOverdrawn(account.ID).ToSDKEvent(),
+	)
+
 	// call hooks
 	for _, hook := range k.hooks.onAccountClosed {
 		hook(ctx, account)
@@ -612,6 +704,8 @@ func (k *keeper) paymentWithdraw(ctx sdk.Context, obj *types.FractionalPayment)
 	rawEarnings := sdk.NewCoin(obj.Balance.Denom, obj.Balance.Amount.TruncateInt())
 
 	if rawEarnings.Amount.IsZero() {
+		// state of closed payment must be persisted even if there is nothing to withdraw
+		k.savePayment(ctx, obj)
 		return nil
 	}
 
@@ -625,6 +719,8 @@ func (k *keeper) paymentWithdraw(ctx sdk.Context, obj *types.FractionalPayment)
 		return err
 	}
 
+	k.tkeeper.AddFeesTaken(ctx, fee)
+
 	if !earnings.IsZero() {
 		if err := k.bkeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, owner, sdk.NewCoins(earnings)); err != nil {
 			ctx.Logger().Error("payment withdraw - earnings", "err", err, "account", obj.AccountID, "payment", obj.PaymentID)
@@ -632,6 +728,10 @@ func (k *keeper) paymentWithdraw(ctx sdk.Context, obj *types.FractionalPayment)
 		}
 	}
 
+	ctx.EventManager().EmitEvent(
+		etypes.NewEventPaymentWithdrawn(obj.AccountID, obj.PaymentID, owner, earnings).ToSDKEvent(),
+	)
+
 	total := earnings.Add(fee)
 
 	obj.Withdrawn = obj.Withdrawn.Add(total)
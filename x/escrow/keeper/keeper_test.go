@@ -8,11 +8,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+	"github.com/akash-network/akash-api/go/sdkutil"
 
 	"github.com/akash-network/node/testutil"
 	"github.com/akash-network/node/testutil/cosmos/mocks"
 	"github.com/akash-network/node/testutil/state"
+	"github.com/akash-network/node/x/escrow"
 	"github.com/akash-network/node/x/escrow/keeper"
+	etypes "github.com/akash-network/node/x/escrow/types"
 )
 
 func Test_AccountCreate(t *testing.T) {
@@ -182,6 +185,35 @@ func Test_Payment_Overdraw(t *testing.T) {
 		assert.Equal(t, testutil.AkashDecCoin(t, 0), payment.Balance)
 	}
 
+	{
+		var overdrawn, withdrawn bool
+
+		for _, sev := range ctx.EventManager().ABCIEvents() {
+			ev, err := sdkutil.ParseEvent(sdk.StringifyEvent(sev))
+			require.NoError(t, err)
+
+			mev, err := etypes.ParseEvent(ev)
+			if err != nil {
+				continue
+			}
+
+			switch mev := mev.(type) {
+			case etypes.EventAccountOverdrawn:
+				assert.Equal(t, aid, mev.ID)
+				overdrawn = true
+			case etypes.EventPaymentWithdrawn:
+				assert.Equal(t, aid, mev.AccountID)
+				assert.Equal(t, pid, mev.PaymentID)
+				assert.Equal(t, powner, mev.Owner)
+				// amount is net of the default take fee
+				assert.True(t, testutil.AkashCoin(t, 980).IsEqual(mev.Amount))
+				withdrawn = true
+			}
+		}
+
+		assert.True(t, overdrawn)
+		assert.True(t, withdrawn)
+	}
 }
 
 func Test_PaymentCreate_later(t *testing.T) {
@@ -213,6 +245,187 @@ func Test_PaymentCreate_later(t *testing.T) {
 	}
 }
 
+func Test_AccountClose_SameBlock(t *testing.T) {
+	ctx, ekeeper, _ := setupKeeper(t)
+	aid := genAccountID(t)
+	aowner := testutil.AccAddress(t)
+
+	amt := testutil.AkashCoin(t, 1000)
+	pid := testutil.Name(t, "payment")
+	powner := testutil.AccAddress(t)
+	rate := testutil.AkashCoin(t, 10)
+
+	// account is settled when payment is created and closed within the same block
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 10)
+	assert.NoError(t, ekeeper.AccountCreate(ctx, aid, aowner, aowner, amt))
+	assert.NoError(t, ekeeper.PaymentCreate(ctx, aid, pid, powner, sdk.NewDecCoinFromCoin(rate)))
+	assert.NoError(t, ekeeper.AccountClose(ctx, aid))
+
+	acct, err := ekeeper.GetAccount(ctx, aid)
+	require.NoError(t, err)
+	require.Equal(t, types.AccountClosed, acct.State)
+
+	payment, err := ekeeper.GetPayment(ctx, aid, pid)
+	require.NoError(t, err)
+	require.Equal(t, types.PaymentClosed, payment.State)
+
+	_, broken := keeper.PaymentStateInvariant(ekeeper)(ctx)
+	require.False(t, broken)
+}
+
+func Test_CloseOrphanedPayments(t *testing.T) {
+	ctx, ekeeper, _ := setupKeeper(t)
+	aid := genAccountID(t)
+	aowner := testutil.AccAddress(t)
+
+	amt := testutil.AkashCoin(t, 1000)
+	pid := testutil.Name(t, "payment")
+	powner := testutil.AccAddress(t)
+	rate := testutil.AkashCoin(t, 10)
+
+	assert.NoError(t, ekeeper.AccountCreate(ctx, aid, aowner, aowner, amt))
+	assert.NoError(t, ekeeper.PaymentCreate(ctx, aid, pid, powner, sdk.NewDecCoinFromCoin(rate)))
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 10)
+	_, err := ekeeper.AccountSettle(ctx, aid)
+	require.NoError(t, err)
+
+	// close account leaving its payment open
+	acct, err := ekeeper.GetAccount(ctx, aid)
+	require.NoError(t, err)
+	acct.State = types.AccountClosed
+	ekeeper.SaveAccount(ctx, acct)
+
+	_, broken := keeper.PaymentStateInvariant(ekeeper)(ctx)
+	require.True(t, broken)
+
+	closed, err := ekeeper.CloseOrphanedPayments(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), closed)
+
+	payment, err := ekeeper.GetPayment(ctx, aid, pid)
+	require.NoError(t, err)
+	require.Equal(t, types.PaymentClosed, payment.State)
+	require.Equal(t, testutil.AkashDecCoin(t, 0), payment.Balance)
+	require.Equal(t, testutil.AkashCoin(t, rate.Amount.Int64()*10), payment.Withdrawn)
+
+	_, broken = keeper.PaymentStateInvariant(ekeeper)(ctx)
+	require.False(t, broken)
+
+	closed, err = ekeeper.CloseOrphanedPayments(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0), closed)
+}
+
+func Test_SettleDueAccounts_Overdrawn(t *testing.T) {
+	ctx, keeper, _ := setupKeeper(t)
+	aid := genAccountID(t)
+	aowner := testutil.AccAddress(t)
+
+	amt := testutil.AkashCoin(t, 1000)
+	pid := testutil.Name(t, "payment")
+	powner := testutil.AccAddress(t)
+	rate := testutil.AkashCoin(t, 10)
+
+	assert.NoError(t, keeper.AccountCreate(ctx, aid, aowner, aowner, amt))
+	assert.NoError(t, keeper.PaymentCreate(ctx, aid, pid, powner, sdk.NewDecCoinFromCoin(rate)))
+
+	// balance covers 100 blocks
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 100)
+	assert.Equal(t, uint32(0), keeper.SettleDueAccounts(ctx))
+
+	{
+		acct, err := keeper.GetAccount(ctx, aid)
+		require.NoError(t, err)
+		require.Equal(t, types.AccountOpen, acct.State)
+	}
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	assert.Equal(t, uint32(1), keeper.SettleDueAccounts(ctx))
+
+	{
+		acct, err := keeper.GetAccount(ctx, aid)
+		require.NoError(t, err)
+		require.Equal(t, types.AccountOverdrawn, acct.State)
+		require.Equal(t, ctx.BlockHeight(), acct.SettledAt)
+
+		payment, err := keeper.GetPayment(ctx, aid, pid)
+		require.NoError(t, err)
+		require.Equal(t, types.PaymentOverdrawn, payment.State)
+	}
+
+	// overdrawn accounts are not settled again
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + keeper.GetParams(ctx).SettlementPeriod)
+	assert.Equal(t, uint32(0), keeper.SettleDueAccounts(ctx))
+}
+
+func Test_SettleDueAccounts_Budget(t *testing.T) {
+	ctx, keeper, _ := setupKeeper(t)
+
+	params := keeper.GetParams(ctx)
+	params.SettlementBlockBudget = 1
+	keeper.SetParams(ctx, params)
+
+	aid1 := genAccountID(t)
+	aid2 := genAccountID(t)
+	owner := testutil.AccAddress(t)
+
+	assert.NoError(t, keeper.AccountCreate(ctx, aid1, owner, owner, testutil.AkashCoin(t, 1000)))
+	assert.NoError(t, keeper.AccountCreate(ctx, aid2, owner, owner, testutil.AkashCoin(t, 1000)))
+
+	// accounts without payments are due once settlement period has elapsed
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + params.SettlementPeriod - 1)
+	assert.Equal(t, uint32(0), keeper.SettleDueAccounts(ctx))
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + 1)
+	assert.Equal(t, uint32(1), keeper.SettleDueAccounts(ctx))
+	assert.Equal(t, uint32(1), keeper.SettleDueAccounts(ctx))
+	assert.Equal(t, uint32(0), keeper.SettleDueAccounts(ctx))
+
+	for _, aid := range []types.AccountID{aid1, aid2} {
+		acct, err := keeper.GetAccount(ctx, aid)
+		require.NoError(t, err)
+		require.Equal(t, types.AccountOpen, acct.State)
+		require.Equal(t, ctx.BlockHeight(), acct.SettledAt)
+	}
+
+	// zero budget disables automatic settlement
+	params.SettlementBlockBudget = 0
+	keeper.SetParams(ctx, params)
+
+	ctx = ctx.WithBlockHeight(ctx.BlockHeight() + params.SettlementPeriod)
+	assert.Equal(t, uint32(0), keeper.SettleDueAccounts(ctx))
+}
+
+func Test_ParamsGenesis(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+	ctx, ekeeper := suite.Context(), suite.EscrowKeeper()
+	cdc := suite.App().AppCodec()
+
+	params := keeper.Params{
+		SettlementBlockBudget: 7,
+		SettlementPeriod:      200,
+	}
+	ekeeper.SetParams(ctx, params)
+
+	bz, err := escrow.MarshalGenesis(cdc, escrow.ExportGenesis(ctx, ekeeper))
+	require.NoError(t, err)
+
+	data, err := escrow.UnmarshalGenesis(cdc, bz)
+	require.NoError(t, err)
+	require.NoError(t, escrow.ValidateGenesis(data))
+
+	imported := state.SetupTestSuite(t)
+	escrow.InitGenesis(imported.Context(), imported.EscrowKeeper(), data)
+
+	require.Equal(t, params, imported.EscrowKeeper().GetParams(imported.Context()))
+
+	// genesis of the API carries no params
+	data, err = escrow.UnmarshalGenesis(cdc, cdc.MustMarshalJSON(&types.GenesisState{}))
+	require.NoError(t, err)
+	require.Equal(t, keeper.DefaultParams(), data.Params)
+}
+
 func genAccountID(t testing.TB) types.AccountID {
 	t.Helper()
 	return types.AccountID{
@@ -3,9 +3,18 @@ package keeper
 import (
 	"bytes"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
 	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
 )
 
+var (
+	// SettlementQueuePrefix indexes open accounts by the height they are due for settlement
+	SettlementQueuePrefix = []byte{0x03}
+	// SettlementSchedulePrefix maps open accounts to their current position in the settlement queue
+	SettlementSchedulePrefix = []byte{0x04}
+)
+
 func accountKey(id types.AccountID) []byte {
 	// TODO: validate scope, xid
 	buf := bytes.Buffer{}
@@ -41,3 +50,82 @@ func paymentKey(id types.AccountID, pid string) []byte {
 	buf.WriteString(pid)
 	return buf.Bytes()
 }
+
+// accountsFilterPrefix returns the narrowest account store prefix for the given filters.
+// Filters that cannot be expressed as a key prefix must be applied by the caller.
+func accountsFilterPrefix(scope, xid string) []byte {
+	buf := bytes.Buffer{}
+	buf.Write(types.AccountKeyPrefix())
+
+	if scope == "" {
+		return buf.Bytes()
+	}
+
+	buf.WriteRune('/')
+	buf.WriteString(scope)
+	buf.WriteRune('/')
+
+	if xid != "" {
+		buf.WriteString(xid)
+	}
+
+	return buf.Bytes()
+}
+
+// paymentsFilterPrefix returns the narrowest payment store prefix for the given filters.
+// Filters that cannot be expressed as a key prefix must be applied by the caller.
+func paymentsFilterPrefix(scope, xid, pid string) []byte {
+	buf := bytes.Buffer{}
+	buf.Write(types.PaymentKeyPrefix())
+
+	if scope == "" {
+		return buf.Bytes()
+	}
+
+	buf.WriteRune('/')
+	buf.WriteString(scope)
+	buf.WriteRune('/')
+
+	if xid == "" {
+		return buf.Bytes()
+	}
+
+	buf.WriteString(xid)
+	buf.WriteRune('/')
+
+	if pid != "" {
+		buf.WriteString(pid)
+	}
+
+	return buf.Bytes()
+}
+
+// SettlementQueueKey returns the settlement queue key of the account due at given height.
+// Height is encoded big endian so that iteration yields accounts in due order.
+func SettlementQueueKey(height int64, id types.AccountID) []byte {
+	buf := bytes.Buffer{}
+	buf.Write(settlementQueueHeightPrefix(height))
+	buf.WriteRune('/')
+	buf.WriteString(id.Scope)
+	buf.WriteRune('/')
+	buf.WriteString(id.XID)
+	return buf.Bytes()
+}
+
+func settlementQueueHeightPrefix(height int64) []byte {
+	buf := bytes.Buffer{}
+	buf.Write(SettlementQueuePrefix)
+	buf.Write(sdk.Uint64ToBigEndian(uint64(height)))
+	return buf.Bytes()
+}
+
+// SettlementScheduleKey returns the key storing the height at which the account is queued for settlement
+func SettlementScheduleKey(id types.AccountID) []byte {
+	buf := bytes.Buffer{}
+	buf.Write(SettlementSchedulePrefix)
+	buf.WriteRune('/')
+	buf.WriteString(id.Scope)
+	buf.WriteRune('/')
+	buf.WriteString(id.XID)
+	return buf.Bytes()
+}
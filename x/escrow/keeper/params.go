@@ -0,0 +1,83 @@
+package keeper
+
+import (
+	"fmt"
+
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+const (
+	keySettlementBlockBudget = "SettlementBlockBudget"
+	keySettlementPeriod      = "SettlementPeriod"
+)
+
+const (
+	// DefaultSettlementBlockBudget is the default maximum number of accounts settled per block
+	DefaultSettlementBlockBudget uint32 = 100
+	// DefaultSettlementPeriod is the default maximum number of blocks an open account may go unsettled (~24h)
+	DefaultSettlementPeriod int64 = 14400
+)
+
+var _ paramtypes.ParamSet = (*Params)(nil)
+
+// Params defines the governance-tunable parameters of the escrow module.
+// They are carried in escrow genesis next to the API genesis fields.
+// Values missing from the param store fall back to DefaultParams.
+type Params struct {
+	// SettlementBlockBudget is the maximum number of accounts settled by the EndBlocker in a single block.
+	// Zero disables automatic settlement.
+	SettlementBlockBudget uint32 `json:"settlement_block_budget" yaml:"settlement_block_budget"`
+	// SettlementPeriod is the maximum number of blocks an open account may go without being settled.
+	SettlementPeriod int64 `json:"settlement_period" yaml:"settlement_period"`
+}
+
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair([]byte(keySettlementBlockBudget), &p.SettlementBlockBudget, validateSettlementBlockBudget),
+		paramtypes.NewParamSetPair([]byte(keySettlementPeriod), &p.SettlementPeriod, validateSettlementPeriod),
+	}
+}
+
+func DefaultParams() Params {
+	return Params{
+		SettlementBlockBudget: DefaultSettlementBlockBudget,
+		SettlementPeriod:      DefaultSettlementPeriod,
+	}
+}
+
+func (p Params) Validate() error {
+	if err := validateSettlementBlockBudget(p.SettlementBlockBudget); err != nil {
+		return err
+	}
+
+	if err := validateSettlementPeriod(p.SettlementPeriod); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateSettlementBlockBudget(i interface{}) error {
+	if _, ok := i.(uint32); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
+
+func validateSettlementPeriod(i interface{}) error {
+	val, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if val <= 0 {
+		return fmt.Errorf("settlement period must be positive: %d", val)
+	}
+
+	return nil
+}
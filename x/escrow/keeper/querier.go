@@ -1,9 +1,175 @@
 package keeper
 
 import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+
 	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
 )
 
+// Querier is used as Keeper will have duplicate methods if used directly, and gRPC names take precedence over keeper
+type Querier struct {
+	Keeper
+}
+
+var _ types.QueryServer = Querier{}
+
 func NewQuerier(k Keeper) types.QueryServer {
-	return nil
+	return Querier{Keeper: k}
+}
+
+// Accounts returns escrow accounts based on filters
+func (k Querier) Accounts(c context.Context, req *types.QueryAccountsRequest) (*types.QueryAccountsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.Xid != "" && req.Scope == "" {
+		return nil, status.Error(codes.InvalidArgument, "xid filter requires scope")
+	}
+
+	stateVal := types.Account_State(types.Account_State_value[req.State])
+	if req.State != "" && stateVal == types.AccountStateInvalid {
+		return nil, status.Error(codes.InvalidArgument, "invalid state value")
+	}
+
+	if req.Owner != "" {
+		if _, err := sdk.AccAddressFromBech32(req.Owner); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid owner address")
+		}
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	store := prefix.NewStore(ctx.KVStore(k.StoreKey()), accountsFilterPrefix(req.Scope, req.Xid))
+
+	var accounts []types.Account
+
+	pageRes, err := sdkquery.FilteredPaginate(store, req.Pagination, func(_ []byte, value []byte, accumulate bool) (bool, error) {
+		var account types.Account
+
+		if err := k.Codec().Unmarshal(value, &account); err != nil {
+			return false, err
+		}
+
+		if !acceptAccount(account, req, stateVal) {
+			return false, nil
+		}
+
+		if accumulate {
+			accounts = append(accounts, account)
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryAccountsResponse{
+		Accounts:   accounts,
+		Pagination: pageRes,
+	}, nil
+}
+
+// Payments returns escrow fractional payments based on filters
+func (k Querier) Payments(c context.Context, req *types.QueryPaymentsRequest) (*types.QueryPaymentsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	if req.Xid != "" && req.Scope == "" {
+		return nil, status.Error(codes.InvalidArgument, "xid filter requires scope")
+	}
+
+	stateVal := types.FractionalPayment_State(types.FractionalPayment_State_value[req.State])
+	if req.State != "" && stateVal == types.PaymentStateInvalid {
+		return nil, status.Error(codes.InvalidArgument, "invalid state value")
+	}
+
+	if req.Owner != "" {
+		if _, err := sdk.AccAddressFromBech32(req.Owner); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid owner address")
+		}
+	}
+
+	ctx := sdk.UnwrapSDKContext(c)
+	store := prefix.NewStore(ctx.KVStore(k.StoreKey()), paymentsFilterPrefix(req.Scope, req.Xid, req.Id))
+
+	var payments []types.FractionalPayment
+
+	pageRes, err := sdkquery.FilteredPaginate(store, req.Pagination, func(_ []byte, value []byte, accumulate bool) (bool, error) {
+		var payment types.FractionalPayment
+
+		if err := k.Codec().Unmarshal(value, &payment); err != nil {
+			return false, err
+		}
+
+		if !acceptPayment(payment, req, stateVal) {
+			return false, nil
+		}
+
+		if accumulate {
+			payments = append(payments, payment)
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &types.QueryPaymentsResponse{
+		Payments:   payments,
+		Pagination: pageRes,
+	}, nil
+}
+
+func acceptAccount(obj types.Account, req *types.QueryAccountsRequest, state types.Account_State) bool {
+	if req.Scope != "" && obj.ID.Scope != req.Scope {
+		return false
+	}
+
+	if req.Xid != "" && obj.ID.XID != req.Xid {
+		return false
+	}
+
+	if req.Owner != "" && obj.Owner != req.Owner {
+		return false
+	}
+
+	if state != types.AccountStateInvalid && obj.State != state {
+		return false
+	}
+
+	return true
+}
+
+func acceptPayment(obj types.FractionalPayment, req *types.QueryPaymentsRequest, state types.FractionalPayment_State) bool {
+	if req.Scope != "" && obj.AccountID.Scope != req.Scope {
+		return false
+	}
+
+	if req.Xid != "" && obj.AccountID.XID != req.Xid {
+		return false
+	}
+
+	if req.Id != "" && obj.PaymentID != req.Id {
+		return false
+	}
+
+	if req.Owner != "" && obj.Owner != req.Owner {
+		return false
+	}
+
+	if state != types.PaymentStateInvalid && obj.State != state {
+		return false
+	}
+
+	return true
 }
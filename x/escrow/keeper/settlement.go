@@ -0,0 +1,119 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+)
+
+// SettleDueAccounts settles open accounts which reached their scheduled settlement height,
+// up to SettlementBlockBudget accounts per call. It returns number of settled accounts.
+// Accounts that turn out to be overdrawn are closed via the regular onAccountClosed/onPaymentClosed hooks.
+func (k *keeper) SettleDueAccounts(ctx sdk.Context) uint32 {
+	params := k.GetParams(ctx)
+	if params.SettlementBlockBudget == 0 {
+		return 0
+	}
+
+	ids := k.dueAccounts(ctx, params.SettlementBlockBudget)
+
+	for _, id := range ids {
+		k.unscheduleSettlement(ctx, id)
+
+		// settle within cache context so failed settlement does not leave partially written state
+		cctx, write := ctx.CacheContext()
+
+		if _, _, _, err := k.doAccountSettle(cctx, id); err != nil {
+			ctx.Logger().Error("scheduled account settle", "err", err, "id", id)
+
+			// retry no sooner than after a full settlement period
+			k.scheduleSettlementAt(ctx, id, ctx.BlockHeight()+params.SettlementPeriod)
+			continue
+		}
+
+		write()
+
+		k.scheduleSettlement(ctx, id)
+	}
+
+	return uint32(len(ids))
+}
+
+// dueAccounts returns up to limit accounts with scheduled settlement height at or below current block height
+func (k *keeper) dueAccounts(ctx sdk.Context, limit uint32) []types.AccountID {
+	store := ctx.KVStore(k.skey)
+	iter := store.Iterator(SettlementQueuePrefix, settlementQueueHeightPrefix(ctx.BlockHeight()+1))
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	ids := make([]types.AccountID, 0, limit)
+
+	for ; iter.Valid() && uint32(len(ids)) < limit; iter.Next() {
+		var id types.AccountID
+		k.cdc.MustUnmarshal(iter.Value(), &id)
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// scheduleSettlement (re)places account into settlement queue. Account is due either
+// when SettlementPeriod has elapsed since it has been settled or once its balance no longer
+// covers block rate of its open payments, whichever comes first.
+// Accounts which are not open are removed from the queue.
+func (k *keeper) scheduleSettlement(ctx sdk.Context, id types.AccountID) {
+	account, err := k.GetAccount(ctx, id)
+	if err != nil || account.State != types.AccountOpen {
+		k.unscheduleSettlement(ctx, id)
+		return
+	}
+
+	params := k.GetParams(ctx)
+
+	height := account.SettledAt + params.SettlementPeriod
+
+	blockRate := sdk.NewDecCoin(account.Balance.Denom, sdk.ZeroInt())
+	for _, payment := range k.accountOpenPayments(ctx, id) {
+		blockRate = blockRate.Add(payment.Rate)
+	}
+
+	if blockRate.IsPositive() {
+		// account is overdrawn once height delta exceeds number of fully paid blocks
+		numFullBlocks := account.TotalBalance().Amount.Quo(blockRate.Amount).TruncateInt()
+		if numFullBlocks.LT(sdk.NewInt(params.SettlementPeriod)) {
+			if overdrawnAt := account.SettledAt + numFullBlocks.Int64() + 1; overdrawnAt < height {
+				height = overdrawnAt
+			}
+		}
+	}
+
+	if height < ctx.BlockHeight() {
+		height = ctx.BlockHeight()
+	}
+
+	k.scheduleSettlementAt(ctx, id, height)
+}
+
+func (k *keeper) scheduleSettlementAt(ctx sdk.Context, id types.AccountID, height int64) {
+	k.unscheduleSettlement(ctx, id)
+
+	store := ctx.KVStore(k.skey)
+
+	store.Set(SettlementQueueKey(height, id), k.cdc.MustMarshal(&id))
+	store.Set(SettlementScheduleKey(id), sdk.Uint64ToBigEndian(uint64(height)))
+}
+
+func (k *keeper) unscheduleSettlement(ctx sdk.Context, id types.AccountID) {
+	store := ctx.KVStore(k.skey)
+	key := SettlementScheduleKey(id)
+
+	buf := store.Get(key)
+	if buf == nil {
+		return
+	}
+
+	store.Delete(SettlementQueueKey(int64(sdk.BigEndianToUint64(buf)), id))
+	store.Delete(key)
+}
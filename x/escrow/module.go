@@ -19,15 +19,20 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	sim "github.com/cosmos/cosmos-sdk/types/simulation"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	v1beta1types "github.com/akash-network/akash-api/go/node/escrow/v1beta1"
 	v1beta2types "github.com/akash-network/akash-api/go/node/escrow/v1beta2"
 	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
 
+	dkeeper "github.com/akash-network/node/x/deployment/keeper"
 	"github.com/akash-network/node/x/escrow/client/cli"
 	"github.com/akash-network/node/x/escrow/client/rest"
 	"github.com/akash-network/node/x/escrow/keeper"
 	"github.com/akash-network/node/x/escrow/query"
+	"github.com/akash-network/node/x/escrow/simulation"
+	mkeeper "github.com/akash-network/node/x/market/keeper"
 )
 
 var (
@@ -61,7 +66,12 @@ func (b AppModuleBasic) RegisterInterfaces(registry cdctypes.InterfaceRegistry)
 // DefaultGenesis returns default genesis state as raw bytes for the provider
 // module.
 func (AppModuleBasic) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
-	return cdc.MustMarshalJSON(DefaultGenesisState())
+	bz, err := MarshalGenesis(cdc, DefaultGenesisState())
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
 }
 
 // ValidateGenesis validation check of the Genesis
@@ -70,14 +80,12 @@ func (AppModuleBasic) ValidateGenesis(cdc codec.JSONCodec, _ client.TxEncodingCo
 		return nil
 	}
 
-	var data types.GenesisState
-
-	err := cdc.UnmarshalJSON(bz, &data)
+	data, err := UnmarshalGenesis(cdc, bz)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal %s genesis state: %v", types.ModuleName, err)
 	}
 
-	return ValidateGenesis(&data)
+	return ValidateGenesis(data)
 }
 
 // RegisterRESTRoutes registers rest routes for this module
@@ -119,14 +127,16 @@ func (AppModuleBasic) GetQueryClient(clientCtx client.Context) types.QueryClient
 // AppModule implements an application module for the audit module.
 type AppModule struct {
 	AppModuleBasic
-	keeper keeper.Keeper
+	keeper  keeper.Keeper
+	bkeeper keeper.BankKeeper
 }
 
 // NewAppModule creates a new AppModule object
-func NewAppModule(cdc codec.Codec, k keeper.Keeper) AppModule {
+func NewAppModule(cdc codec.Codec, k keeper.Keeper, bkeeper keeper.BankKeeper) AppModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{cdc: cdc},
 		keeper:         k,
+		bkeeper:        bkeeper,
 	}
 }
 
@@ -136,7 +146,9 @@ func (AppModule) Name() string {
 }
 
 // RegisterInvariants registers module invariants
-func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	keeper.RegisterInvariants(ir, am.keeper, am.bkeeper)
+}
 
 // Route returns the message routing key for the audit module.
 func (am AppModule) Route() sdk.Route {
@@ -169,50 +181,71 @@ func (am AppModule) RegisterQueryService(server grpc.Server) {
 // BeginBlock performs no-op
 func (am AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
 
-// EndBlock returns the end blocker for the audit module. It returns no auditor
-// updates.
+// EndBlock returns the end blocker for the escrow module. It settles accounts
+// due for settlement within per-block budget. It returns no validator updates.
 func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	am.keeper.SettleDueAccounts(ctx)
 	return []abci.ValidatorUpdate{}
 }
 
 // InitGenesis performs genesis initialization for the audit module. It returns
 // no validator updates.
 func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.RawMessage) []abci.ValidatorUpdate {
-	var genesisState types.GenesisState
-	cdc.MustUnmarshalJSON(data, &genesisState)
-	return InitGenesis(ctx, am.keeper, &genesisState)
+	genesisState, err := UnmarshalGenesis(cdc, data)
+	if err != nil {
+		panic(err)
+	}
+
+	return InitGenesis(ctx, am.keeper, genesisState)
 }
 
 // ExportGenesis returns the exported genesis state as raw bytes for the audit
 // module.
 func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.RawMessage {
-	gs := ExportGenesis(ctx, am.keeper)
-	return cdc.MustMarshalJSON(gs)
+	bz, err := MarshalGenesis(cdc, ExportGenesis(ctx, am.keeper))
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
 }
 
 // ConsensusVersion implements module.AppModule#ConsensusVersion
 func (am AppModule) ConsensusVersion() uint64 {
-	return 2
+	return 3
 }
 
 // ____________________________________________________________________________
 
 // AppModuleSimulation implements an application simulation module for the audit module.
 type AppModuleSimulation struct {
-	keeper keeper.Keeper
+	keepers simulation.Keepers
+	akeeper govtypes.AccountKeeper
 }
 
 // NewAppModuleSimulation creates a new AppModuleSimulation instance
-func NewAppModuleSimulation(k keeper.Keeper) AppModuleSimulation {
+func NewAppModuleSimulation(
+	k keeper.Keeper,
+	akeeper govtypes.AccountKeeper,
+	dkeeper dkeeper.IKeeper,
+	mkeeper mkeeper.IKeeper,
+	bkeeper bankkeeper.Keeper,
+) AppModuleSimulation {
 	return AppModuleSimulation{
-		keeper: k,
+		keepers: simulation.Keepers{
+			Escrow:     k,
+			Deployment: dkeeper,
+			Market:     mkeeper,
+			Bank:       bkeeper,
+		},
+		akeeper: akeeper,
 	}
 }
 
 // AppModuleSimulation functions
 // GenerateGenesisState creates a randomized GenState of the staking module.
 func (AppModuleSimulation) GenerateGenesisState(simState *module.SimulationState) {
-	// simulation.RandomizedGenState(simState)
+	simulation.RandomizedGenState(simState)
 }
 
 // ProposalContents doesn't return any content functions for governance proposals.
@@ -232,5 +265,6 @@ func (AppModuleSimulation) RegisterStoreDecoder(sdr sdk.StoreDecoderRegistry) {
 
 // WeightedOperations returns the all the staking module operations with their respective weights.
 func (am AppModuleSimulation) WeightedOperations(simState module.SimulationState) []sim.WeightedOperation {
-	return nil
+	return simulation.WeightedOperations(simState.AppParams, simState.Cdc,
+		am.akeeper, am.keepers)
 }
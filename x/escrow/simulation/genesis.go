@@ -0,0 +1,34 @@
+package simulation
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	types "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+)
+
+// RandomizedGenState generates an empty escrow genesis. Deployment deposits and lease prices
+// are accepted in uakt only, while simulation accounts are funded in the bond denom, thus
+// it also funds every simulation account with uakt so escrow accounts and payments can be
+// exercised by the simulation operations.
+func RandomizedGenState(simState *module.SimulationState) {
+	simState.GenState[types.ModuleName] = simState.Cdc.MustMarshalJSON(&types.GenesisState{})
+
+	bankGenesisBz, ok := simState.GenState[banktypes.ModuleName]
+	if !ok {
+		return
+	}
+
+	bankGenesis := &banktypes.GenesisState{}
+	simState.Cdc.MustUnmarshalJSON(bankGenesisBz, bankGenesis)
+
+	funds := sdk.NewCoin(minDeposit.Denom, sdk.NewInt(simState.InitialStake))
+
+	for idx := range bankGenesis.Balances {
+		bankGenesis.Balances[idx].Coins = bankGenesis.Balances[idx].Coins.Add(funds)
+		bankGenesis.Supply = bankGenesis.Supply.Add(funds)
+	}
+
+	simState.GenState[banktypes.ModuleName] = simState.Cdc.MustMarshalJSON(bankGenesis)
+}
@@ -0,0 +1,290 @@
+package simulation
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	"github.com/cosmos/cosmos-sdk/x/simulation"
+
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	appparams "github.com/akash-network/node/app/params"
+	testsim "github.com/akash-network/node/testutil/sim"
+)
+
+// Simulation operation weights constants
+const (
+	OpWeightMsgDepositDeployment = "op_weight_msg_deposit_deployment"       // nolint gosec
+	OpWeightMsgCreateLease       = "op_weight_msg_create_lease"             // nolint gosec
+	OpWeightMsgWithdrawLease     = "op_weight_msg_withdraw_lease"           // nolint gosec
+	OpWeightMsgCloseAccount      = "op_weight_msg_close_deployment_account" // nolint gosec
+)
+
+// WeightedOperations returns all the operations from the module with their respective weights.
+// Escrow has no messages of its own, operations exercise escrow accounts and payments
+// through deployment and market messages.
+func WeightedOperations(
+	appParams simtypes.AppParams, cdc codec.JSONCodec, ak govtypes.AccountKeeper,
+	ks Keepers) simulation.WeightedOperations {
+	var (
+		weightMsgDepositDeployment int
+		weightMsgCreateLease       int
+		weightMsgWithdrawLease     int
+		weightMsgCloseAccount      int
+	)
+
+	appParams.GetOrGenerate(
+		cdc, OpWeightMsgDepositDeployment, &weightMsgDepositDeployment, nil, func(r *rand.Rand) {
+			weightMsgDepositDeployment = appparams.DefaultWeightMsgDepositDeployment
+		},
+	)
+
+	appParams.GetOrGenerate(
+		cdc, OpWeightMsgCreateLease, &weightMsgCreateLease, nil, func(r *rand.Rand) {
+			weightMsgCreateLease = appparams.DefaultWeightMsgCreateLease
+		},
+	)
+
+	appParams.GetOrGenerate(
+		cdc, OpWeightMsgWithdrawLease, &weightMsgWithdrawLease, nil, func(r *rand.Rand) {
+			weightMsgWithdrawLease = appparams.DefaultWeightMsgWithdrawLease
+		},
+	)
+
+	appParams.GetOrGenerate(
+		cdc, OpWeightMsgCloseAccount, &weightMsgCloseAccount, nil, func(r *rand.Rand) {
+			weightMsgCloseAccount = appparams.DefaultWeightMsgCloseEscrowAccount
+		},
+	)
+
+	return simulation.WeightedOperations{
+		simulation.NewWeightedOperation(
+			weightMsgDepositDeployment,
+			SimulateMsgDepositDeployment(ak, ks),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgCreateLease,
+			SimulateMsgCreateLease(ak, ks),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgWithdrawLease,
+			SimulateMsgWithdrawLease(ak, ks),
+		),
+		simulation.NewWeightedOperation(
+			weightMsgCloseAccount,
+			SimulateMsgCloseAccount(ak, ks),
+		),
+	}
+}
+
+// SimulateMsgDepositDeployment generates a MsgDepositDeployment of random amount into
+// the escrow account of a random active deployment
+func SimulateMsgDepositDeployment(ak govtypes.AccountKeeper, ks Keepers) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accounts []simtypes.Account,
+		chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		deployments := getActiveDeployments(ctx, ks)
+		if len(deployments) == 0 {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeDepositDeployment, "no deployments found"), nil, nil
+		}
+
+		deployment := deployments[testsim.RandIdx(r, len(deployments)-1)]
+
+		owner, convertErr := sdk.AccAddressFromBech32(deployment.ID().Owner)
+		if convertErr != nil {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeDepositDeployment, "error while converting address"), nil, convertErr
+		}
+
+		simAccount, found := simtypes.FindAccount(accounts, owner)
+		if !found {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeDepositDeployment, "unable to find deployment owner"), nil,
+				fmt.Errorf("deployment with %s not found", deployment.ID().Owner)
+		}
+
+		account := ak.GetAccount(ctx, simAccount.Address)
+		spendable := ks.Bank.SpendableCoins(ctx, account.GetAddress())
+
+		// keep at least a half of the balance for fees and other operations
+		available := spendable.AmountOf(minDeposit.Denom).QuoRaw(2)
+		if available.LT(sdk.OneInt()) {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeDepositDeployment, "out of money"), nil, nil
+		}
+
+		maxAmount := minDeposit.Amount.MulRaw(10)
+		if available.LT(maxAmount) {
+			maxAmount = available
+		}
+
+		amount, err := simtypes.RandPositiveInt(r, maxAmount)
+		if err != nil {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeDepositDeployment, "unable to generate deposit"), nil, err
+		}
+
+		deposit := sdk.NewCoin(minDeposit.Denom, amount)
+		spendable = spendable.Sub(sdk.NewCoins(deposit))
+
+		fees, err := simtypes.RandomFees(r, ctx, spendable)
+		if err != nil {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeDepositDeployment, "unable to generate fees"), nil, err
+		}
+
+		msg := dtypes.NewMsgDepositDeployment(deployment.ID(), deposit, simAccount.Address.String())
+
+		return deliver(app, chainID, dtypes.ModuleName, msg, fees, account, simAccount)
+	}
+}
+
+// SimulateMsgCreateLease generates a MsgCreateLease accepting a random open bid, which opens
+// an escrow payment from the deployment account to the provider
+func SimulateMsgCreateLease(ak govtypes.AccountKeeper, ks Keepers) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accounts []simtypes.Account,
+		chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		var bids []mtypes.Bid
+
+		ks.Market.WithBids(ctx, func(bid mtypes.Bid) bool {
+			if bid.State != mtypes.BidOpen {
+				return false
+			}
+
+			if order, found := ks.Market.GetOrder(ctx, bid.ID().OrderID()); !found || order.State != mtypes.OrderOpen {
+				return false
+			}
+
+			if group, found := ks.Deployment.GetGroup(ctx, bid.ID().GroupID()); !found || group.State != dtypes.GroupOpen {
+				return false
+			}
+
+			account, err := ks.Escrow.GetAccount(ctx, dtypes.EscrowAccountForDeployment(bid.ID().DeploymentID()))
+			if err != nil || account.State != etypes.AccountOpen {
+				return false
+			}
+
+			bids = append(bids, bid)
+
+			return false
+		})
+
+		if len(bids) == 0 {
+			return simtypes.NoOpMsg(mtypes.ModuleName, mtypes.MsgTypeCreateLease, "no open bids found"), nil, nil
+		}
+
+		bid := bids[testsim.RandIdx(r, len(bids)-1)]
+
+		owner, convertErr := sdk.AccAddressFromBech32(bid.ID().Owner)
+		if convertErr != nil {
+			return simtypes.NoOpMsg(mtypes.ModuleName, mtypes.MsgTypeCreateLease, "error while converting address"), nil, convertErr
+		}
+
+		simAccount, found := simtypes.FindAccount(accounts, owner)
+		if !found {
+			return simtypes.NoOpMsg(mtypes.ModuleName, mtypes.MsgTypeCreateLease, "unable to find bid owner"), nil,
+				fmt.Errorf("bid with %s not found", bid.ID().Owner)
+		}
+
+		account := ak.GetAccount(ctx, simAccount.Address)
+		spendable := ks.Bank.SpendableCoins(ctx, account.GetAddress())
+
+		fees, err := simtypes.RandomFees(r, ctx, spendable)
+		if err != nil {
+			return simtypes.NoOpMsg(mtypes.ModuleName, mtypes.MsgTypeCreateLease, "unable to generate fees"), nil, err
+		}
+
+		msg := mtypes.NewMsgCreateLease(bid.ID())
+
+		return deliver(app, chainID, mtypes.ModuleName, msg, fees, account, simAccount)
+	}
+}
+
+// SimulateMsgWithdrawLease generates a MsgWithdrawLease for a random active lease, which settles
+// the deployment escrow account and withdraws the payment balance to the provider
+func SimulateMsgWithdrawLease(ak govtypes.AccountKeeper, ks Keepers) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accounts []simtypes.Account,
+		chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		leases := getActiveLeases(ctx, ks)
+		if len(leases) == 0 {
+			return simtypes.NoOpMsg(mtypes.ModuleName, mtypes.MsgTypeWithdrawLease, "no active leases found"), nil, nil
+		}
+
+		lease := leases[testsim.RandIdx(r, len(leases)-1)]
+
+		provider, convertErr := sdk.AccAddressFromBech32(lease.ID().Provider)
+		if convertErr != nil {
+			return simtypes.NoOpMsg(mtypes.ModuleName, mtypes.MsgTypeWithdrawLease, "error while converting address"), nil, convertErr
+		}
+
+		simAccount, found := simtypes.FindAccount(accounts, provider)
+		if !found {
+			return simtypes.NoOpMsg(mtypes.ModuleName, mtypes.MsgTypeWithdrawLease, "unable to find lease provider"), nil,
+				fmt.Errorf("lease with %s not found", lease.ID().Provider)
+		}
+
+		account := ak.GetAccount(ctx, simAccount.Address)
+		spendable := ks.Bank.SpendableCoins(ctx, account.GetAddress())
+
+		fees, err := simtypes.RandomFees(r, ctx, spendable)
+		if err != nil {
+			return simtypes.NoOpMsg(mtypes.ModuleName, mtypes.MsgTypeWithdrawLease, "unable to generate fees"), nil, err
+		}
+
+		msg := mtypes.NewMsgWithdrawLease(lease.ID())
+
+		return deliver(app, chainID, mtypes.ModuleName, msg, fees, account, simAccount)
+	}
+}
+
+// SimulateMsgCloseAccount generates a MsgCloseDeployment for a random deployment with active leases,
+// which settles and closes the deployment escrow account along with all of its payments
+func SimulateMsgCloseAccount(ak govtypes.AccountKeeper, ks Keepers) simtypes.Operation {
+	return func(r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, accounts []simtypes.Account,
+		chainID string) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+		var deployments []dtypes.DeploymentID
+
+		seen := make(map[string]bool)
+
+		for _, lease := range getActiveLeases(ctx, ks) {
+			did := lease.ID().DeploymentID()
+			if seen[did.String()] {
+				continue
+			}
+
+			seen[did.String()] = true
+			deployments = append(deployments, did)
+		}
+
+		if len(deployments) == 0 {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeCloseDeployment, "no deployments with active leases found"), nil, nil
+		}
+
+		did := deployments[testsim.RandIdx(r, len(deployments)-1)]
+
+		owner, convertErr := sdk.AccAddressFromBech32(did.Owner)
+		if convertErr != nil {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeCloseDeployment, "error while converting address"), nil, convertErr
+		}
+
+		simAccount, found := simtypes.FindAccount(accounts, owner)
+		if !found {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeCloseDeployment, "unable to find deployment owner"), nil,
+				fmt.Errorf("deployment with %s not found", did.Owner)
+		}
+
+		account := ak.GetAccount(ctx, simAccount.Address)
+		spendable := ks.Bank.SpendableCoins(ctx, account.GetAddress())
+
+		fees, err := simtypes.RandomFees(r, ctx, spendable)
+		if err != nil {
+			return simtypes.NoOpMsg(dtypes.ModuleName, dtypes.MsgTypeCloseDeployment, "unable to generate fees"), nil, err
+		}
+
+		msg := dtypes.NewMsgCloseDeployment(did)
+
+		return deliver(app, chainID, dtypes.ModuleName, msg, fees, account, simAccount)
+	}
+}
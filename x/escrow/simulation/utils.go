@@ -0,0 +1,95 @@
+package simulation
+
+import (
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/simapp/helpers"
+	simappparams "github.com/cosmos/cosmos-sdk/simapp/params"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	dkeeper "github.com/akash-network/node/x/deployment/keeper"
+	"github.com/akash-network/node/x/escrow/keeper"
+	mkeeper "github.com/akash-network/node/x/market/keeper"
+)
+
+var minDeposit, _ = dtypes.DefaultParams().MinDepositFor("uakt")
+
+// Keepers include all keepers escrow simulation operations depend on
+type Keepers struct {
+	Escrow     keeper.Keeper
+	Deployment dkeeper.IKeeper
+	Market     mkeeper.IKeeper
+	Bank       bankkeeper.Keeper
+}
+
+func getActiveDeployments(ctx sdk.Context, ks Keepers) []dtypes.Deployment {
+	var deployments []dtypes.Deployment
+
+	ks.Deployment.WithDeployments(ctx, func(deployment dtypes.Deployment) bool {
+		if deployment.State == dtypes.DeploymentActive {
+			deployments = append(deployments, deployment)
+		}
+
+		return false
+	})
+
+	return deployments
+}
+
+func getActiveLeases(ctx sdk.Context, ks Keepers) []mtypes.Lease {
+	var leases []mtypes.Lease
+
+	ks.Market.WithLeases(ctx, func(lease mtypes.Lease) bool {
+		if lease.State == mtypes.LeaseActive {
+			leases = append(leases, lease)
+		}
+
+		return false
+	})
+
+	return leases
+}
+
+func deliver(
+	app *baseapp.BaseApp,
+	chainID string,
+	route string,
+	msg legacyMsg,
+	fees sdk.Coins,
+	account authtypes.AccountI,
+	simAccount simtypes.Account,
+) (simtypes.OperationMsg, []simtypes.FutureOperation, error) {
+	txGen := simappparams.MakeTestEncodingConfig().TxConfig
+	tx, err := helpers.GenTx(
+		txGen,
+		[]sdk.Msg{msg},
+		fees,
+		helpers.DefaultGenTxGas,
+		chainID,
+		[]uint64{account.GetAccountNumber()},
+		[]uint64{account.GetSequence()},
+		simAccount.PrivKey,
+	)
+	if err != nil {
+		return simtypes.NoOpMsg(route, msg.Type(), "unable to generate mock tx"), nil, err
+	}
+
+	_, _, err = app.Deliver(txGen.TxEncoder(), tx)
+	if err != nil {
+		return simtypes.NoOpMsg(route, msg.Type(), "unable to deliver mock tx"), nil, err
+	}
+
+	return simtypes.NewOperationMsg(msg, true, "", nil), nil, nil
+}
+
+type legacyMsg interface {
+	sdk.Msg
+	Type() string
+	Route() string
+	GetSignBytes() []byte
+}
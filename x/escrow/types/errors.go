@@ -0,0 +1,10 @@
+package types
+
+import (
+	"errors"
+)
+
+var (
+	// ErrParsingAmount indicates amount attribute of an event could not be parsed
+	ErrParsingAmount = errors.New("error parsing amount")
+)
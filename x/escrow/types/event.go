@@ -0,0 +1,200 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+	"github.com/akash-network/akash-api/go/sdkutil"
+)
+
+const (
+	evActionAccountClosed    = "account-closed"
+	evActionAccountOverdrawn = "account-overdrawn"
+	evActionPaymentWithdrawn = "payment-withdrawn"
+	evScopeKey               = "scope"
+	evXIDKey                 = "xid"
+	evPaymentIDKey           = "payment-id"
+	evOwnerKey               = "owner"
+	evAmountDenomKey         = "amount-denom"
+	evAmountKey              = "amount"
+)
+
+// EventAccountClosed struct
+type EventAccountClosed struct {
+	Context sdkutil.BaseModuleEvent `json:"context"`
+	ID      etypes.AccountID        `json:"id"`
+}
+
+// NewEventAccountClosed initializes account closed event
+func NewEventAccountClosed(id etypes.AccountID) EventAccountClosed {
+	return EventAccountClosed{
+		Context: sdkutil.BaseModuleEvent{
+			Module: etypes.ModuleName,
+			Action: evActionAccountClosed,
+		},
+		ID: id,
+	}
+}
+
+// ToSDKEvent method creates new sdk event for EventAccountClosed struct
+func (ev EventAccountClosed) ToSDKEvent() sdk.Event {
+	return sdk.NewEvent(sdkutil.EventTypeMessage,
+		append([]sdk.Attribute{
+			sdk.NewAttribute(sdk.AttributeKeyModule, etypes.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeyAction, evActionAccountClosed),
+		}, AccountIDEVAttributes(ev.ID)...)...,
+	)
+}
+
+// EventAccountOverdrawn struct
+type EventAccountOverdrawn struct {
+	Context sdkutil.BaseModuleEvent `json:"context"`
+	ID      etypes.AccountID        `json:"id"`
+}
+
+// NewEventAccountOverdrawn initializes account overdrawn event
+func NewEventAccountOverdrawn(id etypes.AccountID) EventAccountOverdrawn {
+	return EventAccountOverdrawn{
+		Context: sdkutil.BaseModuleEvent{
+			Module: etypes.ModuleName,
+			Action: evActionAccountOverdrawn,
+		},
+		ID: id,
+	}
+}
+
+// ToSDKEvent method creates new sdk event for EventAccountOverdrawn struct
+func (ev EventAccountOverdrawn) ToSDKEvent() sdk.Event {
+	return sdk.NewEvent(sdkutil.EventTypeMessage,
+		append([]sdk.Attribute{
+			sdk.NewAttribute(sdk.AttributeKeyModule, etypes.ModuleName),
+			sdk.NewAttribute(sdk.AttributeKeyAction, evActionAccountOverdrawn),
+		}, AccountIDEVAttributes(ev.ID)...)...,
+	)
+}
+
+// EventPaymentWithdrawn struct
+type EventPaymentWithdrawn struct {
+	Context   sdkutil.BaseModuleEvent `json:"context"`
+	AccountID etypes.AccountID        `json:"account_id"`
+	PaymentID string                  `json:"payment_id"`
+	Owner     sdk.AccAddress          `json:"owner"`
+	Amount    sdk.Coin                `json:"amount"`
+}
+
+// NewEventPaymentWithdrawn initializes payment withdrawn event.
+// Amount is the value transferred to the payment owner, net of take fees.
+func NewEventPaymentWithdrawn(id etypes.AccountID, pid string, owner sdk.AccAddress, amount sdk.Coin) EventPaymentWithdrawn {
+	return EventPaymentWithdrawn{
+		Context: sdkutil.BaseModuleEvent{
+			Module: etypes.ModuleName,
+			Action: evActionPaymentWithdrawn,
+		},
+		AccountID: id,
+		PaymentID: pid,
+		Owner:     owner,
+		Amount:    amount,
+	}
+}
+
+// ToSDKEvent method creates new sdk event for EventPaymentWithdrawn struct
+func (ev EventPaymentWithdrawn) ToSDKEvent() sdk.Event {
+	attrs := []sdk.Attribute{
+		sdk.NewAttribute(sdk.AttributeKeyModule, etypes.ModuleName),
+		sdk.NewAttribute(sdk.AttributeKeyAction, evActionPaymentWithdrawn),
+		sdk.NewAttribute(evPaymentIDKey, ev.PaymentID),
+		sdk.NewAttribute(evOwnerKey, ev.Owner.String()),
+		sdk.NewAttribute(evAmountDenomKey, ev.Amount.Denom),
+		sdk.NewAttribute(evAmountKey, ev.Amount.Amount.String()),
+	}
+
+	return sdk.NewEvent(sdkutil.EventTypeMessage, append(attrs, AccountIDEVAttributes(ev.AccountID)...)...)
+}
+
+// AccountIDEVAttributes returns event attributes for given AccountID
+func AccountIDEVAttributes(id etypes.AccountID) []sdk.Attribute {
+	return []sdk.Attribute{
+		sdk.NewAttribute(evScopeKey, id.Scope),
+		sdk.NewAttribute(evXIDKey, id.XID),
+	}
+}
+
+// ParseEVAccountID returns AccountID details for given event attributes
+func ParseEVAccountID(attrs []sdk.Attribute) (etypes.AccountID, error) {
+	scope, err := sdkutil.GetString(attrs, evScopeKey)
+	if err != nil {
+		return etypes.AccountID{}, err
+	}
+
+	xid, err := sdkutil.GetString(attrs, evXIDKey)
+	if err != nil {
+		return etypes.AccountID{}, err
+	}
+
+	return etypes.AccountID{
+		Scope: scope,
+		XID:   xid,
+	}, nil
+}
+
+func parseEVPaymentWithdrawn(attrs []sdk.Attribute) (EventPaymentWithdrawn, error) {
+	id, err := ParseEVAccountID(attrs)
+	if err != nil {
+		return EventPaymentWithdrawn{}, err
+	}
+
+	pid, err := sdkutil.GetString(attrs, evPaymentIDKey)
+	if err != nil {
+		return EventPaymentWithdrawn{}, err
+	}
+
+	owner, err := sdkutil.GetAccAddress(attrs, evOwnerKey)
+	if err != nil {
+		return EventPaymentWithdrawn{}, err
+	}
+
+	denom, err := sdkutil.GetString(attrs, evAmountDenomKey)
+	if err != nil {
+		return EventPaymentWithdrawn{}, err
+	}
+
+	amounts, err := sdkutil.GetString(attrs, evAmountKey)
+	if err != nil {
+		return EventPaymentWithdrawn{}, err
+	}
+
+	amount, ok := sdk.NewIntFromString(amounts)
+	if !ok {
+		return EventPaymentWithdrawn{}, ErrParsingAmount
+	}
+
+	return NewEventPaymentWithdrawn(id, pid, owner, sdk.NewCoin(denom, amount)), nil
+}
+
+// ParseEvent parses event and returns details of event and error if occurred
+func ParseEvent(ev sdkutil.Event) (sdkutil.ModuleEvent, error) {
+	if ev.Type != sdkutil.EventTypeMessage {
+		return nil, sdkutil.ErrUnknownType
+	}
+	if ev.Module != etypes.ModuleName {
+		return nil, sdkutil.ErrUnknownModule
+	}
+	switch ev.Action {
+	case evActionAccountClosed:
+		id, err := ParseEVAccountID(ev.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return NewEventAccountClosed(id), nil
+	case evActionAccountOverdrawn:
+		id, err := ParseEVAccountID(ev.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return NewEventAccountOverdrawn(id), nil
+	case evActionPaymentWithdrawn:
+		return parseEVPaymentWithdrawn(ev.Attributes)
+	default:
+		return nil, sdkutil.ErrUnknownAction
+	}
+}
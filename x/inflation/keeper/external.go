@@ -0,0 +1,13 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+)
+
+type MintKeeper interface {
+	GetMinter(ctx sdk.Context) minttypes.Minter
+	GetParams(ctx sdk.Context) minttypes.Params
+	StakingTokenSupply(ctx sdk.Context) sdk.Int
+	BondedRatio(ctx sdk.Context) sdk.Dec
+}
@@ -0,0 +1,140 @@
+package keeper
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+
+	types "github.com/akash-network/akash-api/go/node/inflation/v1beta3"
+)
+
+const (
+	// secondsPerYear averages leap years: 60 * 60 * 8766
+	secondsPerYear = 31557600
+	// inflationCoefPrecision truncates decay coefficient to 6 decimal places
+	inflationCoefPrecision = 1000000
+)
+
+var ln2 = sdk.MustNewDecFromStr("0.693147180559945309")
+
+// Inflation describes inflation of the next block derived from inflation and mint params
+type Inflation struct {
+	// IdealInflation is the annual inflation rate decayed since genesis
+	IdealInflation sdk.Dec `json:"ideal_inflation" yaml:"ideal_inflation"`
+	// MinInflation and MaxInflation bound inflation rate at IdealInflation -/+ Variance
+	MinInflation sdk.Dec `json:"min_inflation" yaml:"min_inflation"`
+	MaxInflation sdk.Dec `json:"max_inflation" yaml:"max_inflation"`
+	// Inflation is the annual inflation rate applied to the next block
+	Inflation sdk.Dec `json:"inflation" yaml:"inflation"`
+	// AnnualProvisions is the amount of tokens minted per year at Inflation rate
+	AnnualProvisions sdk.Dec `json:"annual_provisions" yaml:"annual_provisions"`
+	// BlockProvision is the amount of tokens minted in the next block
+	BlockProvision sdk.Coin `json:"block_provision" yaml:"block_provision"`
+}
+
+// IdealInflation returns annual inflation rate at block time.
+// InitialInflation halves every InflationDecayFactor years since genesis.
+func (k Keeper) IdealInflation(ctx sdk.Context) sdk.Dec {
+	return idealInflation(ctx.BlockTime(), k.genesisTime, k.GetParams(ctx))
+}
+
+// InflationCalculationFn defines the function x/mint uses to calculate inflation rate of the next block.
+// x/mint of the SDK in use has no such hook, see NewMintAppModule.
+type InflationCalculationFn func(ctx sdk.Context, minter minttypes.Minter, params minttypes.Params, bondedRatio sdk.Dec) sdk.Dec
+
+// InflationCalculator returns function calculating mint inflation rate bounded
+// to IdealInflation -/+ Variance. Mint params are left intact.
+// Without known genesis time inflation rate is bounded by mint params.
+func (k Keeper) InflationCalculator() InflationCalculationFn {
+	return func(ctx sdk.Context, minter minttypes.Minter, params minttypes.Params, bondedRatio sdk.Dec) sdk.Dec {
+		if k.genesisTime.IsZero() {
+			return minter.NextInflationRate(params, bondedRatio)
+		}
+
+		return inflationCalculator(ctx.BlockTime(), k.genesisTime, minter, params, k.GetParams(ctx), bondedRatio)
+	}
+}
+
+// GetInflation returns inflation and provisions of the next block
+func (k Keeper) GetInflation(ctx sdk.Context) Inflation {
+	iparams := k.GetParams(ctx)
+	ideal := k.IdealInflation(ctx)
+	min, max := inflationBounds(ideal, iparams.Variance)
+
+	mparams := k.mkeeper.GetParams(ctx)
+
+	minter := k.mkeeper.GetMinter(ctx)
+	minter.Inflation = k.InflationCalculator()(ctx, minter, mparams, k.mkeeper.BondedRatio(ctx))
+	minter.AnnualProvisions = minter.NextAnnualProvisions(mparams, k.mkeeper.StakingTokenSupply(ctx))
+
+	return Inflation{
+		IdealInflation:   ideal,
+		MinInflation:     min,
+		MaxInflation:     max,
+		Inflation:        minter.Inflation,
+		AnnualProvisions: minter.AnnualProvisions,
+		BlockProvision:   minter.BlockProvision(mparams),
+	}
+}
+
+// inflationCalculator calculates inflation rate of the next block the way x/mint does,
+// bounded to [I(t) - I(t) * R, I(t) + I(t) * R] instead of mint InflationMin and InflationMax.
+// - btime - block time from sdk.Context
+// - gtime - genesis time
+func inflationCalculator(btime, gtime time.Time, minter minttypes.Minter, mparams minttypes.Params, iparams types.Params, bondedRatio sdk.Dec) sdk.Dec {
+	mparams.InflationMin, mparams.InflationMax = inflationBounds(idealInflation(btime, gtime, iparams), iparams.Variance)
+
+	return minter.NextInflationRate(mparams, bondedRatio)
+}
+
+// idealInflation calculates InitialInflation * 2^(-t/InflationDecayFactor)
+// - btime - block time from sdk.Context
+// - gtime - genesis time
+func idealInflation(btime, gtime time.Time, params types.Params) sdk.Dec {
+	elapsed := btime.Unix() - gtime.Unix()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+
+	// years passed since genesis, can be a fraction, eg: 0.5
+	yearsPassed := sdk.NewDec(elapsed).QuoInt64(secondsPerYear)
+
+	coef := pow2Neg(yearsPassed.Quo(params.InflationDecayFactor))
+	coef = coef.MulInt64(inflationCoefPrecision).TruncateDec().QuoInt64(inflationCoefPrecision)
+
+	// InitialInflation is a percentage
+	return params.InitialInflation.QuoInt64(100).Mul(coef)
+}
+
+// inflationBounds returns inflation range [I(t) - I(t) * R, I(t) + I(t) * R]
+// restricted to values accepted by mint params
+func inflationBounds(ideal, variance sdk.Dec) (sdk.Dec, sdk.Dec) {
+	min := sdk.MaxDec(sdk.ZeroDec(), ideal.Sub(ideal.Mul(variance)))
+	max := sdk.MinDec(sdk.OneDec(), ideal.Add(ideal.Mul(variance)))
+
+	return sdk.MinDec(min, max), max
+}
+
+// pow2Neg returns 2^(-x) for x >= 0
+func pow2Neg(x sdk.Dec) sdk.Dec {
+	n := x.TruncateInt64()
+
+	// 2^(-60) is beyond sdk.Dec precision
+	if n >= 60 {
+		return sdk.ZeroDec()
+	}
+
+	// 2^(-f) = e^(-f * ln2), series converges quickly as f * ln2 < 1
+	z := x.Sub(sdk.NewDec(n)).Mul(ln2).Neg()
+
+	res := sdk.OneDec()
+	term := sdk.OneDec()
+
+	for i := int64(1); i <= 30 && !term.IsZero(); i++ {
+		term = term.Mul(z).QuoInt64(i)
+		res = res.Add(term)
+	}
+
+	return res.QuoInt64(int64(1) << n)
+}
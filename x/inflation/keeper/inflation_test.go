@@ -0,0 +1,87 @@
+package keeper
+
+import (
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	"github.com/stretchr/testify/require"
+
+	types "github.com/akash-network/akash-api/go/node/inflation/v1beta3"
+)
+
+func TestPow2Neg(t *testing.T) {
+	for _, tt := range []struct {
+		x        string
+		expected string
+	}{
+		{x: "0", expected: "1"},
+		{x: "1", expected: "0.5"},
+		{x: "2", expected: "0.25"},
+		{x: "0.5", expected: "0.707106781186547524"},
+		{x: "1.5", expected: "0.353553390593273762"},
+		{x: "60", expected: "0"},
+	} {
+		res := pow2Neg(sdk.MustNewDecFromStr(tt.x))
+		diff := res.Sub(sdk.MustNewDecFromStr(tt.expected)).Abs()
+		require.True(t, diff.LTE(sdk.NewDecWithPrec(1, 15)), "2^-%s = %s", tt.x, res)
+	}
+}
+
+func TestIdealInflation(t *testing.T) {
+	gtime := time.Date(2021, 3, 8, 15, 0, 0, 0, time.UTC)
+	params := types.Params{
+		InflationDecayFactor: sdk.NewDec(2),
+		InitialInflation:     sdk.NewDec(48),
+		Variance:             sdk.MustNewDecFromStr("0.05"),
+	}
+
+	year := time.Duration(secondsPerYear) * time.Second
+
+	require.Equal(t, sdk.MustNewDecFromStr("0.48"), idealInflation(gtime, gtime, params))
+	require.Equal(t, sdk.MustNewDecFromStr("0.48"), idealInflation(gtime.Add(-year), gtime, params))
+	require.Equal(t, sdk.MustNewDecFromStr("0.24"), idealInflation(gtime.Add(2*year), gtime, params))
+	require.Equal(t, sdk.MustNewDecFromStr("0.12"), idealInflation(gtime.Add(4*year), gtime, params))
+	require.Equal(t, sdk.MustNewDecFromStr("0.33941088"), idealInflation(gtime.Add(year), gtime, params))
+}
+
+func TestInflationBounds(t *testing.T) {
+	min, max := inflationBounds(sdk.MustNewDecFromStr("0.2"), sdk.MustNewDecFromStr("0.05"))
+	require.Equal(t, sdk.MustNewDecFromStr("0.19"), min)
+	require.Equal(t, sdk.MustNewDecFromStr("0.21"), max)
+
+	min, max = inflationBounds(sdk.MustNewDecFromStr("0.8"), sdk.OneDec())
+	require.Equal(t, sdk.ZeroDec(), min)
+	require.Equal(t, sdk.OneDec(), max)
+}
+
+func TestInflationCalculator(t *testing.T) {
+	gtime := time.Date(2021, 3, 8, 15, 0, 0, 0, time.UTC)
+	iparams := types.Params{
+		InflationDecayFactor: sdk.NewDec(2),
+		InitialInflation:     sdk.NewDec(48),
+		Variance:             sdk.MustNewDecFromStr("0.05"),
+	}
+
+	mparams := minttypes.DefaultParams()
+	btime := gtime.Add(2 * time.Duration(secondsPerYear) * time.Second)
+
+	// ideal inflation is 0.24, bounded to [0.228, 0.252] regardless of mint InflationMin and InflationMax
+	minter := minttypes.NewMinter(sdk.MustNewDecFromStr("0.5"), sdk.ZeroDec())
+	require.Equal(t, sdk.MustNewDecFromStr("0.252"), inflationCalculator(btime, gtime, minter, mparams, iparams, sdk.ZeroDec()))
+
+	minter = minttypes.NewMinter(sdk.MustNewDecFromStr("0.01"), sdk.ZeroDec())
+	require.Equal(t, sdk.MustNewDecFromStr("0.228"), inflationCalculator(btime, gtime, minter, mparams, iparams, sdk.OneDec()))
+
+	// within bounds inflation changes at mint InflationRateChange
+	minter = minttypes.NewMinter(sdk.MustNewDecFromStr("0.24"), sdk.ZeroDec())
+	bounded := mparams
+	bounded.InflationMin, bounded.InflationMax = sdk.MustNewDecFromStr("0.228"), sdk.MustNewDecFromStr("0.252")
+
+	bondedRatio := mparams.GoalBonded.QuoInt64(2)
+	require.Equal(t, minter.NextInflationRate(bounded, bondedRatio), inflationCalculator(btime, gtime, minter, mparams, iparams, bondedRatio))
+
+	// mint params are left intact
+	require.Equal(t, minttypes.DefaultParams(), mparams)
+}
@@ -1,6 +1,8 @@
 package keeper
 
 import (
+	"time"
+
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -14,25 +16,32 @@ type IKeeper interface {
 	StoreKey() sdk.StoreKey
 	GetParams(ctx sdk.Context) (params types.Params)
 	SetParams(ctx sdk.Context, params types.Params)
+	IdealInflation(ctx sdk.Context) sdk.Dec
+	GetInflation(ctx sdk.Context) Inflation
+	InflationCalculator() InflationCalculationFn
 }
 
 // Keeper of the deployment store
 type Keeper struct {
-	skey   sdk.StoreKey
-	cdc    codec.BinaryCodec
-	pspace paramtypes.Subspace
+	skey        sdk.StoreKey
+	cdc         codec.BinaryCodec
+	pspace      paramtypes.Subspace
+	mkeeper     MintKeeper
+	genesisTime time.Time
 }
 
 // NewKeeper creates and returns an instance for deployment keeper
-func NewKeeper(cdc codec.BinaryCodec, skey sdk.StoreKey, pspace paramtypes.Subspace) IKeeper {
+func NewKeeper(cdc codec.BinaryCodec, skey sdk.StoreKey, pspace paramtypes.Subspace, mkeeper MintKeeper, genesisTime time.Time) IKeeper {
 	if !pspace.HasKeyTable() {
 		pspace = pspace.WithKeyTable(types.ParamKeyTable())
 	}
 
 	return Keeper{
-		skey:   skey,
-		cdc:    cdc,
-		pspace: pspace,
+		skey:        skey,
+		cdc:         cdc,
+		pspace:      pspace,
+		mkeeper:     mkeeper,
+		genesisTime: genesisTime,
 	}
 }
 
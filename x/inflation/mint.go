@@ -0,0 +1,83 @@
+package inflation
+
+import (
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/telemetry"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+	mintkeeper "github.com/cosmos/cosmos-sdk/x/mint/keeper"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/akash-network/node/x/inflation/keeper"
+)
+
+// MintAppModule is x/mint module which inflation rate is calculated by InflationCalculationFn.
+// x/mint of the SDK in use does not accept inflation calculation function, MintAppModule
+// supplies it the way later SDK releases do.
+type MintAppModule struct {
+	mint.AppModule
+	keeper mintkeeper.Keeper
+	ic     keeper.InflationCalculationFn
+}
+
+// NewMintAppModule creates x/mint module calculating inflation rate with ic
+func NewMintAppModule(cdc codec.Codec, k mintkeeper.Keeper, ak minttypes.AccountKeeper, ic keeper.InflationCalculationFn) MintAppModule {
+	return MintAppModule{
+		AppModule: mint.NewAppModule(cdc, k, ak),
+		keeper:    k,
+		ic:        ic,
+	}
+}
+
+// BeginBlock mints new tokens for the previous block
+func (am MintAppModule) BeginBlock(ctx sdk.Context, _ abci.RequestBeginBlock) {
+	mintBeginBlocker(ctx, am.keeper, am.ic)
+}
+
+// mintBeginBlocker is x/mint BeginBlocker with inflation rate calculated by ic
+func mintBeginBlocker(ctx sdk.Context, k mintkeeper.Keeper, ic keeper.InflationCalculationFn) {
+	defer telemetry.ModuleMeasureSince(minttypes.ModuleName, time.Now(), telemetry.MetricKeyBeginBlocker)
+
+	// fetch stored minter & params
+	minter := k.GetMinter(ctx)
+	params := k.GetParams(ctx)
+
+	// recalculate inflation rate
+	totalStakingSupply := k.StakingTokenSupply(ctx)
+	bondedRatio := k.BondedRatio(ctx)
+	minter.Inflation = ic(ctx, minter, params, bondedRatio)
+	minter.AnnualProvisions = minter.NextAnnualProvisions(params, totalStakingSupply)
+	k.SetMinter(ctx, minter)
+
+	// mint coins, update supply
+	mintedCoin := minter.BlockProvision(params)
+	mintedCoins := sdk.NewCoins(mintedCoin)
+
+	err := k.MintCoins(ctx, mintedCoins)
+	if err != nil {
+		panic(err)
+	}
+
+	// send the minted coins to the fee collector account
+	err = k.AddCollectedFees(ctx, mintedCoins)
+	if err != nil {
+		panic(err)
+	}
+
+	if mintedCoin.Amount.IsInt64() {
+		defer telemetry.ModuleSetGauge(minttypes.ModuleName, float32(mintedCoin.Amount.Int64()), "minted_tokens")
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			minttypes.EventTypeMint,
+			sdk.NewAttribute(minttypes.AttributeKeyBondedRatio, bondedRatio.String()),
+			sdk.NewAttribute(minttypes.AttributeKeyInflation, minter.Inflation.String()),
+			sdk.NewAttribute(minttypes.AttributeKeyAnnualProvisions, minter.AnnualProvisions.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, mintedCoin.Amount.String()),
+		),
+	)
+}
@@ -20,7 +20,9 @@ import (
 
 	types "github.com/akash-network/akash-api/go/node/inflation/v1beta3"
 
+	"github.com/akash-network/node/x/inflation/client/cli"
 	"github.com/akash-network/node/x/inflation/keeper"
+	"github.com/akash-network/node/x/inflation/query"
 	"github.com/akash-network/node/x/inflation/simulation"
 )
 
@@ -73,7 +75,7 @@ func (AppModuleBasic) RegisterGRPCGatewayRoutes(clientCtx client.Context, mux *r
 
 // GetQueryCmd get the root query command of this module
 func (AppModuleBasic) GetQueryCmd() *cobra.Command {
-	return nil
+	return cli.GetQueryCmd()
 }
 
 // GetTxCmd get the root tx command of this module
@@ -110,12 +112,12 @@ func (am AppModule) Route() sdk.Route {
 
 // QuerierRoute returns the module's querier route name.
 func (am AppModule) QuerierRoute() string {
-	return ""
+	return types.ModuleName
 }
 
-// LegacyQuerierHandler returns the sdk.Querier for deployment module
-func (am AppModule) LegacyQuerierHandler(_ *codec.LegacyAmino) sdk.Querier {
-	return nil
+// LegacyQuerierHandler returns the sdk.Querier for inflation module
+func (am AppModule) LegacyQuerierHandler(legacyQuerierCdc *codec.LegacyAmino) sdk.Querier {
+	return query.NewQuerier(am.keeper, legacyQuerierCdc)
 }
 
 // RegisterServices registers the module's services
@@ -0,0 +1,48 @@
+package query
+
+import (
+	"fmt"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+
+	types "github.com/akash-network/akash-api/go/node/inflation/v1beta3"
+
+	"github.com/akash-network/node/x/inflation/keeper"
+)
+
+// Client interface
+type Client interface {
+	Params() (types.Params, error)
+	Inflation() (keeper.Inflation, error)
+}
+
+// NewClient creates a client instance with provided context and key
+func NewClient(ctx sdkclient.Context, key string) Client {
+	return &client{ctx: ctx, key: key}
+}
+
+type client struct {
+	ctx sdkclient.Context
+	key string
+}
+
+func (c *client) Params() (types.Params, error) {
+	var obj types.Params
+	err := c.query(ParamsPath, &obj)
+	return obj, err
+}
+
+func (c *client) Inflation() (keeper.Inflation, error) {
+	var obj keeper.Inflation
+	err := c.query(InflationPath, &obj)
+	return obj, err
+}
+
+func (c *client) query(path string, obj interface{}) error {
+	buf, _, err := c.ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", c.key, path), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.ctx.LegacyAmino.UnmarshalJSON(buf, obj)
+}
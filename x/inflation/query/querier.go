@@ -0,0 +1,46 @@
+package query
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	types "github.com/akash-network/akash-api/go/node/inflation/v1beta3"
+
+	"github.com/akash-network/node/x/inflation/keeper"
+)
+
+const (
+	// ParamsPath is the query path of the inflation module params
+	ParamsPath = "params"
+	// InflationPath is the query path of the inflation computed for the next block
+	InflationPath = "inflation"
+)
+
+// NewQuerier creates and returns a new inflation querier instance
+func NewQuerier(k keeper.IKeeper, cdc *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "empty %s query path", types.ModuleName)
+		}
+
+		switch path[0] {
+		case ParamsPath:
+			return marshalJSON(cdc, k.GetParams(ctx))
+		case InflationPath:
+			return marshalJSON(cdc, k.GetInflation(ctx))
+		}
+
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint: %s", types.ModuleName, path[0])
+	}
+}
+
+func marshalJSON(cdc *codec.LegacyAmino, obj interface{}) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, obj)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
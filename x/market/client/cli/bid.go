@@ -8,6 +8,7 @@ import (
 	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
 
 	aclient "github.com/akash-network/node/client"
+	"github.com/akash-network/node/x/market/query"
 )
 
 func cmdGetBids() *cobra.Command {
@@ -96,3 +97,48 @@ func cmdGetBid() *cobra.Command {
 
 	return cmd
 }
+
+func cmdSearchBids() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Query bids by creation height, price and order requirements",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := sdkclient.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			filters, err := BidSearchFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := searchPageRequest(cmd)
+			if err != nil {
+				return err
+			}
+
+			buf, err := query.NewRawClient(cctx, types.StoreKey).FilterBids(query.FilterBidsRequest{
+				Filters:    filters,
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			var res query.FilterBidsResponse
+			if err = cctx.LegacyAmino.UnmarshalJSON(buf, &res); err != nil {
+				return err
+			}
+
+			return cctx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "bids")
+	AddBidSearchFlags(cmd.Flags())
+
+	return cmd
+}
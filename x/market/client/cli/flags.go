@@ -1,15 +1,35 @@
 package cli
 
 import (
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"strings"
 
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
 
 	dcli "github.com/akash-network/node/x/deployment/client/cli"
+	"github.com/akash-network/node/x/market/keeper"
+)
+
+const (
+	FlagCreatedFrom = "created-from"
+	FlagCreatedTo   = "created-to"
+	FlagPriceDenom  = "price-denom"
+	FlagMinPrice    = "min-price"
+	FlagMaxPrice    = "max-price"
+	FlagAttribute   = "attribute"
+	FlagMinCPU      = "min-cpu"
+	FlagMinMemory   = "min-memory"
+	FlagMinGPU      = "min-gpu"
 )
 
 var (
@@ -208,3 +228,148 @@ func LeaseFiltersFromFlags(flags *pflag.FlagSet) (types.LeaseFilters, error) {
 	}
 	return types.LeaseFilters(bfilters), nil
 }
+
+// AddBidSearchFlags add flags to search bids
+func AddBidSearchFlags(flags *pflag.FlagSet) {
+	AddBidFilterFlags(flags)
+	addSearchFlags(flags)
+}
+
+// BidSearchFromFlags returns BidQueryFilters with given flags and error if occurred
+func BidSearchFromFlags(flags *pflag.FlagSet) (keeper.BidQueryFilters, error) {
+	bfilters, err := BidFiltersFromFlags(flags)
+	if err != nil {
+		return keeper.BidQueryFilters{}, err
+	}
+
+	// search spans all groups and orders unless sequences are given explicitly
+	if !flags.Changed("gseq") {
+		bfilters.GSeq = 0
+	}
+
+	if !flags.Changed("oseq") {
+		bfilters.OSeq = 0
+	}
+
+	filters := keeper.BidQueryFilters{
+		BidFilters: bfilters,
+	}
+
+	filters.CreatedAt, filters.Price, filters.Order, err = searchFromFlags(flags)
+
+	return filters, err
+}
+
+// AddLeaseSearchFlags add flags to search leases
+func AddLeaseSearchFlags(flags *pflag.FlagSet) {
+	AddLeaseFilterFlags(flags)
+	addSearchFlags(flags)
+}
+
+// LeaseSearchFromFlags returns LeaseQueryFilters with given flags and error if occurred
+func LeaseSearchFromFlags(flags *pflag.FlagSet) (keeper.LeaseQueryFilters, error) {
+	bfilters, err := BidSearchFromFlags(flags)
+	if err != nil {
+		return keeper.LeaseQueryFilters{}, err
+	}
+
+	return keeper.LeaseQueryFilters{
+		LeaseFilters: types.LeaseFilters(bfilters.BidFilters),
+		CreatedAt:    bfilters.CreatedAt,
+		Price:        bfilters.Price,
+		Order:        bfilters.Order,
+	}, nil
+}
+
+func addSearchFlags(flags *pflag.FlagSet) {
+	flags.Int64(FlagCreatedFrom, 0, "lowest creation block height to filter")
+	flags.Int64(FlagCreatedTo, 0, "highest creation block height to filter")
+	flags.String(FlagPriceDenom, "", "price denomination to filter")
+	flags.String(FlagMinPrice, "", "lowest price to filter")
+	flags.String(FlagMaxPrice, "", "highest price to filter")
+	flags.StringArray(FlagAttribute, nil, "order attribute to filter, key or key=value. may be repeated")
+	flags.Uint64(FlagMinCPU, 0, "minimum CPU requested by order to filter, in millicpu")
+	flags.Uint64(FlagMinMemory, 0, "minimum memory requested by order to filter, in bytes")
+	flags.Uint64(FlagMinGPU, 0, "minimum GPU units requested by order to filter")
+}
+
+func searchFromFlags(flags *pflag.FlagSet) (keeper.HeightRange, keeper.PriceRange, keeper.OrderPredicate, error) {
+	var hrange keeper.HeightRange
+	var prange keeper.PriceRange
+	var predicate keeper.OrderPredicate
+	var err error
+
+	if hrange.From, err = flags.GetInt64(FlagCreatedFrom); err != nil {
+		return hrange, prange, predicate, err
+	}
+
+	if hrange.To, err = flags.GetInt64(FlagCreatedTo); err != nil {
+		return hrange, prange, predicate, err
+	}
+
+	if prange.Denom, err = flags.GetString(FlagPriceDenom); err != nil {
+		return hrange, prange, predicate, err
+	}
+
+	if prange.Min, err = decFromFlag(flags, FlagMinPrice); err != nil {
+		return hrange, prange, predicate, err
+	}
+
+	if prange.Max, err = decFromFlag(flags, FlagMaxPrice); err != nil {
+		return hrange, prange, predicate, err
+	}
+
+	attrs, err := flags.GetStringArray(FlagAttribute)
+	if err != nil {
+		return hrange, prange, predicate, err
+	}
+
+	for _, attr := range attrs {
+		key, value, _ := strings.Cut(attr, "=")
+		if key == "" {
+			return hrange, prange, predicate, fmt.Errorf("invalid attribute filter %q", attr)
+		}
+
+		predicate.Attributes = append(predicate.Attributes, atypes.Attribute{Key: key, Value: value})
+	}
+
+	if predicate.CPU, err = flags.GetUint64(FlagMinCPU); err != nil {
+		return hrange, prange, predicate, err
+	}
+
+	if predicate.Memory, err = flags.GetUint64(FlagMinMemory); err != nil {
+		return hrange, prange, predicate, err
+	}
+
+	if predicate.GPU, err = flags.GetUint64(FlagMinGPU); err != nil {
+		return hrange, prange, predicate, err
+	}
+
+	return hrange, prange, predicate, nil
+}
+
+func decFromFlag(flags *pflag.FlagSet, name string) (sdk.Dec, error) {
+	val, err := flags.GetString(name)
+	if err != nil || val == "" {
+		return sdk.Dec{}, err
+	}
+
+	return sdk.NewDecFromStr(val)
+}
+
+// searchPageRequest returns page request of search commands. Search responses are
+// printed as amino JSON, so the next key passed back with --page-key is base64 encoded
+func searchPageRequest(cmd *cobra.Command) (*sdkquery.PageRequest, error) {
+	pageReq, err := sdkclient.ReadPageRequest(cmd.Flags())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pageReq.Key) != 0 {
+		if pageReq.Key, err = base64.StdEncoding.DecodeString(string(pageReq.Key)); err != nil {
+			return nil, fmt.Errorf("--%s: %w", flags.FlagPageKey, err)
+		}
+	}
+
+	return pageReq, nil
+}
@@ -8,6 +8,7 @@ import (
 	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
 
 	aclient "github.com/akash-network/node/client"
+	"github.com/akash-network/node/x/market/query"
 )
 
 func cmdGetLeases() *cobra.Command {
@@ -96,3 +97,48 @@ func cmdGetLease() *cobra.Command {
 
 	return cmd
 }
+
+func cmdSearchLeases() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search",
+		Short: "Query leases by creation height, price and order requirements",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := sdkclient.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			filters, err := LeaseSearchFromFlags(cmd.Flags())
+			if err != nil {
+				return err
+			}
+
+			pageReq, err := searchPageRequest(cmd)
+			if err != nil {
+				return err
+			}
+
+			buf, err := query.NewRawClient(cctx, types.StoreKey).FilterLeases(query.FilterLeasesRequest{
+				Filters:    filters,
+				Pagination: pageReq,
+			})
+			if err != nil {
+				return err
+			}
+
+			var res query.FilterLeasesResponse
+			if err = cctx.LegacyAmino.UnmarshalJSON(buf, &res); err != nil {
+				return err
+			}
+
+			return cctx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+	flags.AddPaginationFlagsToCmd(cmd, "leases")
+	AddLeaseSearchFlags(cmd.Flags())
+
+	return cmd
+}
@@ -21,6 +21,7 @@ func GetQueryCmd() *cobra.Command {
 		getOrderCmd(),
 		getBidCmd(),
 		getLeaseCmd(),
+		cmdGetPriceStats(),
 	)
 
 	return cmd
@@ -53,6 +54,7 @@ func getBidCmd() *cobra.Command {
 	cmd.AddCommand(
 		cmdGetBids(),
 		cmdGetBid(),
+		cmdSearchBids(),
 	)
 
 	return cmd
@@ -69,6 +71,7 @@ func getLeaseCmd() *cobra.Command {
 	cmd.AddCommand(
 		cmdGetLeases(),
 		cmdGetLease(),
+		cmdSearchLeases(),
 	)
 
 	return cmd
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	"github.com/akash-network/node/x/market/keeper"
+	"github.com/akash-network/node/x/market/query"
+)
+
+func cmdGetPriceStats() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "price-stats",
+		Short: "Query min/median/max prices of active leases and open bids per resource bucket",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := sdkclient.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			buf, err := query.NewRawClient(cctx, types.StoreKey).PriceStats()
+			if err != nil {
+				return err
+			}
+
+			var res keeper.MarketPriceStats
+			if err = cctx.LegacyAmino.UnmarshalJSON(buf, &res); err != nil {
+				return err
+			}
+
+			return cctx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -14,25 +14,94 @@ import (
 	keys "github.com/akash-network/node/x/market/keeper/keys/v1beta4"
 )
 
+// GenesisState of the market module. Market genesis of the API can not be extended,
+// expiration params are kept next to its fields.
+type GenesisState struct {
+	types.GenesisState
+	ExpirationParams keeper.ExpirationParams
+}
+
+// genesisExpirationParamsKey is the JSON field of expiration params in market genesis
+const genesisExpirationParamsKey = "expiration_params"
+
+// MarshalGenesis encodes genesis state as JSON. Fields of the API genesis are encoded
+// with the codec to keep its layout.
+func MarshalGenesis(cdc codec.JSONCodec, data *GenesisState) (json.RawMessage, error) {
+	bz, err := cdc.MarshalJSON(&data.GenesisState)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return nil, err
+	}
+
+	if fields[genesisExpirationParamsKey], err = json.Marshal(data.ExpirationParams); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalGenesis decodes genesis state from JSON. Genesis of the API is accepted as is,
+// expiration params missing from it fall back to defaults.
+func UnmarshalGenesis(cdc codec.JSONCodec, bz json.RawMessage) (*GenesisState, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(bz, &fields); err != nil {
+		return nil, err
+	}
+
+	data := &GenesisState{
+		ExpirationParams: keeper.DefaultExpirationParams(),
+	}
+
+	if raw, exists := fields[genesisExpirationParamsKey]; exists {
+		if err := json.Unmarshal(raw, &data.ExpirationParams); err != nil {
+			return nil, err
+		}
+
+		delete(fields, genesisExpirationParamsKey)
+	}
+
+	bz, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cdc.UnmarshalJSON(bz, &data.GenesisState); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
 // ValidateGenesis does validation check of the Genesis
-func ValidateGenesis(data *types.GenesisState) error {
+func ValidateGenesis(data *GenesisState) error {
 	if err := data.Params.Validate(); err != nil {
 		return err
 	}
 
+	if err := data.ExpirationParams.Validate(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
 // DefaultGenesisState returns default genesis state as raw bytes for the market
 // module.
-func DefaultGenesisState() *types.GenesisState {
-	return &types.GenesisState{
-		Params: types.DefaultParams(),
+func DefaultGenesisState() *GenesisState {
+	return &GenesisState{
+		GenesisState: types.GenesisState{
+			Params: types.DefaultParams(),
+		},
+		ExpirationParams: keeper.DefaultExpirationParams(),
 	}
 }
 
 // InitGenesis initiate genesis state and return updated validator details
-func InitGenesis(ctx sdk.Context, kpr keeper.IKeeper, data *types.GenesisState) []abci.ValidatorUpdate {
+func InitGenesis(ctx sdk.Context, kpr keeper.IKeeper, data *GenesisState) []abci.ValidatorUpdate {
 	store := ctx.KVStore(kpr.StoreKey())
 	cdc := kpr.Codec()
 
@@ -44,6 +113,10 @@ func InitGenesis(ctx sdk.Context, kpr keeper.IKeeper, data *types.GenesisState)
 		}
 
 		store.Set(key, cdc.MustMarshal(&record))
+
+		if record.State == types.OrderOpen {
+			store.Set(keys.OrderOpenHeightKey(record.CreatedAt, record.ID()), key)
+		}
 	}
 
 	for _, record := range data.Bids {
@@ -54,6 +127,10 @@ func InitGenesis(ctx sdk.Context, kpr keeper.IKeeper, data *types.GenesisState)
 		}
 
 		store.Set(key, cdc.MustMarshal(&record))
+
+		if record.State == types.BidOpen {
+			store.Set(keys.BidOpenHeightKey(record.CreatedAt, record.ID()), key)
+		}
 	}
 
 	for _, record := range data.Leases {
@@ -66,13 +143,15 @@ func InitGenesis(ctx sdk.Context, kpr keeper.IKeeper, data *types.GenesisState)
 		store.Set(key, cdc.MustMarshal(&record))
 	}
 
+	kpr.RebuildPriceIndex(ctx)
 	kpr.SetParams(ctx, data.Params)
+	kpr.SetExpirationParams(ctx, data.ExpirationParams)
 
 	return []abci.ValidatorUpdate{}
 }
 
 // ExportGenesis returns genesis state as raw bytes for the market module
-func ExportGenesis(ctx sdk.Context, k keeper.IKeeper) *types.GenesisState {
+func ExportGenesis(ctx sdk.Context, k keeper.IKeeper) *GenesisState {
 	params := k.GetParams(ctx)
 
 	var bids []types.Bid
@@ -94,22 +173,30 @@ func ExportGenesis(ctx sdk.Context, k keeper.IKeeper) *types.GenesisState {
 		return false
 	})
 
-	return &types.GenesisState{
-		Params: params,
-		Orders: orders,
-		Leases: leases,
-		Bids:   bids,
+	return &GenesisState{
+		GenesisState: types.GenesisState{
+			Params: params,
+			Orders: orders,
+			Leases: leases,
+			Bids:   bids,
+		},
+		ExpirationParams: k.GetExpirationParams(ctx),
 	}
 }
 
 // GetGenesisStateFromAppState returns x/market GenesisState given raw application
-// genesis state.
-func GetGenesisStateFromAppState(cdc codec.JSONCodec, appState map[string]json.RawMessage) *types.GenesisState {
-	var genesisState types.GenesisState
+// genesis state. It panics if module genesis can not be decoded.
+func GetGenesisStateFromAppState(cdc codec.JSONCodec, appState map[string]json.RawMessage) *GenesisState {
+	if appState[ModuleName] == nil {
+		return &GenesisState{
+			ExpirationParams: keeper.DefaultExpirationParams(),
+		}
+	}
 
-	if appState[ModuleName] != nil {
-		cdc.MustUnmarshalJSON(appState[ModuleName], &genesisState)
+	genesisState, err := UnmarshalGenesis(cdc, appState[ModuleName])
+	if err != nil {
+		panic(err)
 	}
 
-	return &genesisState
+	return genesisState
 }
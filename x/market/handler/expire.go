@@ -0,0 +1,101 @@
+package handler
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+)
+
+// EndBlocker closes orders and bids which stayed open longer than OrderTTL and BidTTL blocks.
+// Closing an order closes all of its open bids and pauses its group, so tenant can restart it
+// with MsgStartGroup. Closing a bid returns its deposit to the provider.
+// At most ExpirationBlockBudget orders and bids are closed per block, the backlog is
+// closed in following blocks.
+func EndBlocker(ctx sdk.Context, keepers Keepers) {
+	params := keepers.Market.GetExpirationParams(ctx)
+
+	budget := params.ExpirationBlockBudget
+
+	if params.OrderTTL > 0 {
+		budget = expireOrders(ctx, keepers, ctx.BlockHeight()-params.OrderTTL, budget)
+	}
+
+	if params.BidTTL > 0 {
+		expireBids(ctx, keepers, ctx.BlockHeight()-params.BidTTL, budget)
+	}
+}
+
+// expireOrders closes expired orders until budget is exhausted, returns budget left
+func expireOrders(ctx sdk.Context, keepers Keepers, height int64, budget uint32) uint32 {
+	if budget == 0 {
+		return 0
+	}
+
+	var orders []types.Order
+
+	// collect first, store must not be modified while iterating
+	keepers.Market.WithExpiredOrders(ctx, height, func(order types.Order) bool {
+		orders = append(orders, order)
+		return uint32(len(orders)) >= budget
+	})
+
+	for _, order := range orders {
+		if budget == 0 {
+			break
+		}
+
+		var bids []types.Bid
+
+		keepers.Market.WithBidsForOrder(ctx, order.ID(), types.BidOpen, func(bid types.Bid) bool {
+			bids = append(bids, bid)
+			return false
+		})
+
+		for _, bid := range bids {
+			keepers.Market.OnBidClosed(ctx, bid)
+		}
+
+		keepers.Market.OnOrderClosed(ctx, order)
+
+		if group, found := keepers.Deployment.GetGroup(ctx, order.ID().GroupID()); found && group.State == dtypes.GroupOpen {
+			if err := keepers.Deployment.OnBidClosed(ctx, group.ID()); err != nil {
+				ctx.Logger().Error("pausing group of expired order", "err", err, "order", order.ID())
+			}
+		}
+
+		ctx.Logger().Info("expired order", "order", order.ID())
+
+		budget = consumeBudget(budget, uint32(len(bids))+1)
+	}
+
+	return budget
+}
+
+// expireBids closes expired bids until budget is exhausted
+func expireBids(ctx sdk.Context, keepers Keepers, height int64, budget uint32) {
+	if budget == 0 {
+		return
+	}
+
+	var bids []types.Bid
+
+	keepers.Market.WithExpiredBids(ctx, height, func(bid types.Bid) bool {
+		bids = append(bids, bid)
+		return uint32(len(bids)) >= budget
+	})
+
+	for _, bid := range bids {
+		keepers.Market.OnBidClosed(ctx, bid)
+
+		ctx.Logger().Info("expired bid", "bid", bid.ID())
+	}
+}
+
+func consumeBudget(budget, cost uint32) uint32 {
+	if cost >= budget {
+		return 0
+	}
+
+	return budget - cost
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/tendermint/tendermint/libs/rand"
 
 	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
 	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
 	ptypes "github.com/akash-network/akash-api/go/node/provider/v1beta3"
 	akashtypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
@@ -21,6 +22,7 @@ import (
 	"github.com/akash-network/node/testutil"
 	"github.com/akash-network/node/testutil/state"
 	"github.com/akash-network/node/x/market/handler"
+	"github.com/akash-network/node/x/market/keeper"
 )
 
 type testSuite struct {
@@ -405,6 +407,166 @@ func TestCloseBidUnknownOrder(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestEndBlockerExpiresOrder(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	suite.SetBlockHeight(1)
+	bid, order := suite.createBid()
+	suite.createBidEscrow(bid)
+
+	params := suite.MarketKeeper().GetExpirationParams(suite.Context())
+	params.BidTTL = 0
+	suite.MarketKeeper().SetExpirationParams(suite.Context(), params)
+
+	suite.SetBlockHeight(params.OrderTTL)
+	handler.EndBlocker(suite.Context(), suite.keepers())
+
+	result, found := suite.MarketKeeper().GetOrder(suite.Context(), order.ID())
+	require.True(t, found)
+	require.Equal(t, types.OrderOpen, result.State)
+
+	suite.SetBlockHeight(params.OrderTTL + 1)
+	handler.EndBlocker(suite.Context(), suite.keepers())
+
+	result, found = suite.MarketKeeper().GetOrder(suite.Context(), order.ID())
+	require.True(t, found)
+	require.Equal(t, types.OrderClosed, result.State)
+
+	rbid, found := suite.MarketKeeper().GetBid(suite.Context(), bid.ID())
+	require.True(t, found)
+	require.Equal(t, types.BidClosed, rbid.State)
+
+	account, err := suite.EscrowKeeper().GetAccount(suite.Context(), types.EscrowAccountForBid(bid.ID()))
+	require.NoError(t, err)
+	require.Equal(t, etypes.AccountClosed, account.State)
+
+	group, found := suite.DeploymentKeeper().GetGroup(suite.Context(), order.ID().GroupID())
+	require.True(t, found)
+	require.Equal(t, dtypes.GroupPaused, group.State)
+}
+
+func TestEndBlockerExpiresBid(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	suite.SetBlockHeight(1)
+	bid, order := suite.createBid()
+	suite.createBidEscrow(bid)
+
+	params := suite.MarketKeeper().GetExpirationParams(suite.Context())
+
+	suite.SetBlockHeight(params.BidTTL + 1)
+	handler.EndBlocker(suite.Context(), suite.keepers())
+
+	rbid, found := suite.MarketKeeper().GetBid(suite.Context(), bid.ID())
+	require.True(t, found)
+	require.Equal(t, types.BidClosed, rbid.State)
+
+	account, err := suite.EscrowKeeper().GetAccount(suite.Context(), types.EscrowAccountForBid(bid.ID()))
+	require.NoError(t, err)
+	require.Equal(t, etypes.AccountClosed, account.State)
+
+	result, found := suite.MarketKeeper().GetOrder(suite.Context(), order.ID())
+	require.True(t, found)
+	require.Equal(t, types.OrderOpen, result.State)
+}
+
+func TestEndBlockerKeepsMatched(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	suite.SetBlockHeight(1)
+	_, bid, order := suite.createLease()
+
+	params := suite.MarketKeeper().GetExpirationParams(suite.Context())
+
+	suite.SetBlockHeight(params.OrderTTL + params.BidTTL + 1)
+	handler.EndBlocker(suite.Context(), suite.keepers())
+
+	rbid, found := suite.MarketKeeper().GetBid(suite.Context(), bid.ID())
+	require.True(t, found)
+	require.Equal(t, types.BidActive, rbid.State)
+
+	result, found := suite.MarketKeeper().GetOrder(suite.Context(), order.ID())
+	require.True(t, found)
+	require.Equal(t, types.OrderActive, result.State)
+}
+
+func TestEndBlockerExpirationDisabled(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	suite.SetBlockHeight(1)
+	bid, order := suite.createBid()
+
+	suite.MarketKeeper().SetExpirationParams(suite.Context(), keeper.ExpirationParams{})
+
+	suite.SetBlockHeight(keeper.DefaultOrderTTL + 1)
+	handler.EndBlocker(suite.Context(), suite.keepers())
+
+	rbid, found := suite.MarketKeeper().GetBid(suite.Context(), bid.ID())
+	require.True(t, found)
+	require.Equal(t, types.BidOpen, rbid.State)
+
+	result, found := suite.MarketKeeper().GetOrder(suite.Context(), order.ID())
+	require.True(t, found)
+	require.Equal(t, types.OrderOpen, result.State)
+}
+
+func TestEndBlockerExpirationBudget(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	suite.SetBlockHeight(1)
+
+	var orders []types.Order
+	for i := 0; i < 3; i++ {
+		bid, order := suite.createBid()
+		suite.createBidEscrow(bid)
+		orders = append(orders, order)
+	}
+
+	params := suite.MarketKeeper().GetExpirationParams(suite.Context())
+	params.BidTTL = 0
+	// an order along with its bid
+	params.ExpirationBlockBudget = 2
+	suite.MarketKeeper().SetExpirationParams(suite.Context(), params)
+
+	closed := func() int {
+		count := 0
+		for _, order := range orders {
+			result, found := suite.MarketKeeper().GetOrder(suite.Context(), order.ID())
+			require.True(t, found)
+			if result.State == types.OrderClosed {
+				count++
+			}
+		}
+		return count
+	}
+
+	for i := 1; i <= len(orders); i++ {
+		suite.SetBlockHeight(params.OrderTTL + int64(i))
+		handler.EndBlocker(suite.Context(), suite.keepers())
+		require.Equal(t, i, closed())
+	}
+}
+
+func (st *testSuite) keepers() handler.Keepers {
+	return handler.Keepers{
+		Escrow:     st.EscrowKeeper(),
+		Audit:      st.AuditKeeper(),
+		Market:     st.MarketKeeper(),
+		Deployment: st.DeploymentKeeper(),
+		Provider:   st.ProviderKeeper(),
+	}
+}
+
+func (st *testSuite) createBidEscrow(bid types.Bid) {
+	st.t.Helper()
+
+	provider, err := sdk.AccAddressFromBech32(bid.ID().Provider)
+	require.NoError(st.t, err)
+
+	err = st.EscrowKeeper().AccountCreate(st.Context(), types.EscrowAccountForBid(bid.ID()), provider, provider, types.DefaultBidMinDeposit)
+	require.NoError(st.t, err)
+}
+
 func (st *testSuite) createLease() (types.LeaseID, types.Bid, types.Order) {
 	st.t.Helper()
 	bid, order := st.createBid()
@@ -19,6 +19,8 @@ type EscrowKeeper interface {
 	PaymentCreate(ctx sdk.Context, id etypes.AccountID, pid string, owner sdk.AccAddress, rate sdk.DecCoin) error
 	PaymentWithdraw(ctx sdk.Context, id etypes.AccountID, pid string) error
 	PaymentClose(ctx sdk.Context, id etypes.AccountID, pid string) error
+	GetAccount(ctx sdk.Context, id etypes.AccountID) (etypes.Account, error)
+	GetPayment(ctx sdk.Context, id etypes.AccountID, pid string) (etypes.FractionalPayment, error)
 }
 
 // ProviderKeeper Interface includes provider methods
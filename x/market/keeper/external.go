@@ -3,6 +3,7 @@ package keeper
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
 	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
 )
 
@@ -12,3 +13,7 @@ type EscrowKeeper interface {
 	AccountClose(ctx sdk.Context, id etypes.AccountID) error
 	PaymentClose(ctx sdk.Context, id etypes.AccountID, pid string) error
 }
+
+type DeploymentKeeper interface {
+	GetGroup(ctx sdk.Context, id dtypes.GroupID) (dtypes.Group, bool)
+}
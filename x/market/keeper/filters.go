@@ -0,0 +1,414 @@
+package keeper
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+
+	keys "github.com/akash-network/node/x/market/keeper/keys/v1beta4"
+)
+
+const (
+	// DefaultFilterLimit is the number of objects returned per page of a filter query unless requested otherwise
+	DefaultFilterLimit = 100
+	// MaxFilterLimit is the maximum number of objects returned per page of a filter query
+	MaxFilterLimit = 1000
+	// filterScanFactor bounds store entries examined per page of a filter query to a multiple of its limit
+	filterScanFactor = 10
+)
+
+// HeightRange matches objects created within [From, To] block heights.
+// Zero value of either bound leaves it open.
+type HeightRange struct {
+	From int64 `json:"from"`
+	To   int64 `json:"to"`
+}
+
+// Contains returns true if height is within the range
+func (r HeightRange) Contains(height int64) bool {
+	if r.From > 0 && height < r.From {
+		return false
+	}
+
+	if r.To > 0 && height > r.To {
+		return false
+	}
+
+	return true
+}
+
+// PriceRange matches prices within [Min, Max] of given denomination.
+// Empty denom matches any denomination, nil or zero bound leaves it open.
+type PriceRange struct {
+	Denom string  `json:"denom"`
+	Min   sdk.Dec `json:"min"`
+	Max   sdk.Dec `json:"max"`
+}
+
+// Contains returns true if price is within the range
+func (r PriceRange) Contains(price sdk.DecCoin) bool {
+	if r.Denom != "" && price.Denom != r.Denom {
+		return false
+	}
+
+	if !r.Min.IsNil() && !r.Min.IsZero() && price.Amount.LT(r.Min) {
+		return false
+	}
+
+	if !r.Max.IsNil() && !r.Max.IsZero() && price.Amount.GT(r.Max) {
+		return false
+	}
+
+	return true
+}
+
+// OrderPredicate matches orders by their placement requirements and requested resources.
+// Attributes with empty value match any value of the key.
+// Resources are minimum totals of the order: CPU in millicpu, memory in bytes and GPU units.
+type OrderPredicate struct {
+	Attributes atypes.Attributes `json:"attributes"`
+	CPU        uint64            `json:"cpu"`
+	Memory     uint64            `json:"memory"`
+	GPU        uint64            `json:"gpu"`
+}
+
+// Empty returns true if predicate matches any order
+func (p OrderPredicate) Empty() bool {
+	return len(p.Attributes) == 0 && p.CPU == 0 && p.Memory == 0 && p.GPU == 0
+}
+
+// Accept returns true if order matches the predicate
+func (p OrderPredicate) Accept(order types.Order) bool {
+	for _, attr := range p.Attributes {
+		if !specHasAttribute(order.Spec, attr) {
+			return false
+		}
+	}
+
+	var cpu, memory, gpu uint64
+
+	for _, unit := range order.Spec.Resources {
+		count := uint64(unit.Count)
+
+		if unit.CPU != nil {
+			cpu += unit.CPU.Units.Value() * count
+		}
+		if unit.Memory != nil {
+			memory += unit.Memory.Quantity.Value() * count
+		}
+		if unit.GPU != nil {
+			gpu += unit.GPU.Units.Value() * count
+		}
+	}
+
+	return cpu >= p.CPU && memory >= p.Memory && gpu >= p.GPU
+}
+
+// BidQueryFilters extends bid filters of the gRPC query with creation height,
+// price and order predicates
+type BidQueryFilters struct {
+	types.BidFilters `json:"filters"`
+	CreatedAt        HeightRange    `json:"created_at"`
+	Price            PriceRange     `json:"price"`
+	Order            OrderPredicate `json:"order"`
+}
+
+// LeaseQueryFilters extends lease filters of the gRPC query with creation height,
+// price and order predicates
+type LeaseQueryFilters struct {
+	types.LeaseFilters `json:"filters"`
+	CreatedAt          HeightRange    `json:"created_at"`
+	Price              PriceRange     `json:"price"`
+	Order              OrderPredicate `json:"order"`
+}
+
+// FilterBids returns page of bids matching filters.
+// Bids of a provider are looked up in the provider index, which covers open and active bids only,
+// thus provider filter without owner requires one of these states.
+func (k Keeper) FilterBids(ctx sdk.Context, filters BidQueryFilters, pageReq *sdkquery.PageRequest) ([]types.Bid, *sdkquery.PageResponse, error) {
+	stateVal := types.Bid_State(types.Bid_State_value[filters.State])
+
+	states := []types.Bid_State{types.BidOpen, types.BidActive, types.BidLost, types.BidClosed}
+	if filters.State != "" {
+		if stateVal == types.BidStateInvalid {
+			return nil, nil, fmt.Errorf("%w: invalid bid state %q", sdkerrors.ErrInvalidRequest, filters.State)
+		}
+		states = []types.Bid_State{stateVal}
+	}
+
+	if err := validateFilterAddresses(filters.Owner, filters.Provider); err != nil {
+		return nil, nil, err
+	}
+
+	reverse := filters.Owner == "" && filters.Provider != ""
+	if reverse && stateVal != types.BidOpen && stateVal != types.BidActive {
+		return nil, nil, fmt.Errorf("%w: bids of provider can be filtered in %s or %s state only",
+			sdkerrors.ErrInvalidRequest, types.BidOpen, types.BidActive)
+	}
+
+	prefixes := make([][]byte, 0, len(states))
+
+	for _, state := range states {
+		bfilters := filters.BidFilters
+		bfilters.State = state.String()
+
+		var prefix []byte
+		var err error
+
+		if reverse {
+			prefix, err = keys.BidReversePrefixFromFilter(bfilters)
+		} else {
+			prefix, err = keys.BidPrefixFromFilter(bfilters)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		prefixes = append(prefixes, prefix)
+	}
+
+	orders := k.orderPredicate(ctx, filters.Order)
+	bids := make([]types.Bid, 0)
+
+	pageRes, err := filterPage(ctx.KVStore(k.skey), prefixes, pageReq, func(value []byte) bool {
+		var bid types.Bid
+		k.cdc.MustUnmarshal(value, &bid)
+
+		if !filters.BidFilters.Accept(bid, stateVal) ||
+			!filters.CreatedAt.Contains(bid.CreatedAt) ||
+			!filters.Price.Contains(bid.Price) ||
+			!orders(bid.BidID.OrderID()) {
+			return false
+		}
+
+		bids = append(bids, bid)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bids, pageRes, nil
+}
+
+// FilterLeases returns page of leases matching filters.
+// Leases of a provider are looked up in the provider index, which covers active leases only,
+// thus provider filter without owner requires active state.
+func (k Keeper) FilterLeases(ctx sdk.Context, filters LeaseQueryFilters, pageReq *sdkquery.PageRequest) ([]types.Lease, *sdkquery.PageResponse, error) {
+	stateVal := types.Lease_State(types.Lease_State_value[filters.State])
+
+	states := []types.Lease_State{types.LeaseActive, types.LeaseInsufficientFunds, types.LeaseClosed}
+	if filters.State != "" {
+		if stateVal == types.LeaseStateInvalid {
+			return nil, nil, fmt.Errorf("%w: invalid lease state %q", sdkerrors.ErrInvalidRequest, filters.State)
+		}
+		states = []types.Lease_State{stateVal}
+	}
+
+	if err := validateFilterAddresses(filters.Owner, filters.Provider); err != nil {
+		return nil, nil, err
+	}
+
+	reverse := filters.Owner == "" && filters.Provider != ""
+	if reverse && stateVal != types.LeaseActive {
+		return nil, nil, fmt.Errorf("%w: leases of provider can be filtered in %s state only",
+			sdkerrors.ErrInvalidRequest, types.LeaseActive)
+	}
+
+	prefixes := make([][]byte, 0, len(states))
+
+	for _, state := range states {
+		lfilters := filters.LeaseFilters
+		lfilters.State = state.String()
+
+		var prefix []byte
+		var err error
+
+		if reverse {
+			prefix, err = keys.LeaseReversePrefixFromFilter(lfilters)
+		} else {
+			prefix, err = keys.LeasePrefixFromFilter(lfilters)
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		prefixes = append(prefixes, prefix)
+	}
+
+	orders := k.orderPredicate(ctx, filters.Order)
+	leases := make([]types.Lease, 0)
+
+	pageRes, err := filterPage(ctx.KVStore(k.skey), prefixes, pageReq, func(value []byte) bool {
+		var lease types.Lease
+		k.cdc.MustUnmarshal(value, &lease)
+
+		if !filters.LeaseFilters.Accept(lease, stateVal) ||
+			!filters.CreatedAt.Contains(lease.CreatedAt) ||
+			!filters.Price.Contains(lease.Price) ||
+			!orders(lease.LeaseID.OrderID()) {
+			return false
+		}
+
+		leases = append(leases, lease)
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return leases, pageRes, nil
+}
+
+// orderPredicate returns matcher of order ids against predicate.
+// Orders are loaded once per query as many bids and leases may refer to the same order.
+func (k Keeper) orderPredicate(ctx sdk.Context, predicate OrderPredicate) func(types.OrderID) bool {
+	if predicate.Empty() {
+		return func(types.OrderID) bool {
+			return true
+		}
+	}
+
+	matches := make(map[string]bool)
+
+	return func(id types.OrderID) bool {
+		key := id.String()
+
+		if match, cached := matches[key]; cached {
+			return match
+		}
+
+		order, found := k.GetOrder(ctx, id)
+		match := found && predicate.Accept(order)
+		matches[key] = match
+
+		return match
+	}
+}
+
+func validateFilterAddresses(addrs ...string) error {
+	for _, addr := range addrs {
+		if addr == "" {
+			continue
+		}
+
+		if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// filterPage iterates store entries under prefixes, in order, passing values to accept
+// until it accepted page limit of them. Page key resumes iteration at the store key it holds.
+// Number of entries examined per page is bounded as well, so page may hold fewer entries
+// than its limit while NextKey is set.
+func filterPage(store sdk.KVStore, prefixes [][]byte, pageReq *sdkquery.PageRequest, accept func([]byte) bool) (*sdkquery.PageResponse, error) {
+	var start []byte
+	limit := uint64(DefaultFilterLimit)
+
+	if pageReq != nil {
+		if pageReq.Offset != 0 || pageReq.CountTotal || pageReq.Reverse {
+			return nil, fmt.Errorf("%w: only key and limit of pagination are supported", sdkerrors.ErrInvalidRequest)
+		}
+
+		if pageReq.Limit > MaxFilterLimit {
+			return nil, fmt.Errorf("%w: limit %d exceeds maximum of %d", sdkerrors.ErrInvalidRequest, pageReq.Limit, MaxFilterLimit)
+		}
+
+		if pageReq.Limit != 0 {
+			limit = pageReq.Limit
+		}
+
+		start = pageReq.Key
+	}
+
+	resumed := len(start) == 0
+	maxScanned := limit * filterScanFactor
+
+	var accepted, scanned uint64
+
+	for _, prefix := range prefixes {
+		from := prefix
+
+		if !resumed {
+			if !bytes.HasPrefix(start, prefix) {
+				continue
+			}
+
+			from = start
+			resumed = true
+		}
+
+		iter := store.Iterator(from, sdk.PrefixEndBytes(prefix))
+
+		for ; iter.Valid(); iter.Next() {
+			if accepted == limit || scanned == maxScanned {
+				nextKey := append([]byte{}, iter.Key()...)
+				_ = iter.Close()
+
+				return &sdkquery.PageResponse{NextKey: nextKey}, nil
+			}
+
+			scanned++
+
+			if accept(iter.Value()) {
+				accepted++
+			}
+		}
+
+		_ = iter.Close()
+	}
+
+	if !resumed {
+		return nil, fmt.Errorf("%w: page key does not match filters", sdkerrors.ErrInvalidRequest)
+	}
+
+	return &sdkquery.PageResponse{}, nil
+}
+
+// specHasAttribute returns true if attribute is among placement requirements
+// or attributes of any resource requested by the group spec
+func specHasAttribute(spec dtypes.GroupSpec, attr atypes.Attribute) bool {
+	match := func(attrs atypes.Attributes) bool {
+		for _, val := range attrs {
+			if val.Key == attr.Key && (attr.Value == "" || val.Value == attr.Value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if match(spec.Requirements.Attributes) {
+		return true
+	}
+
+	for _, unit := range spec.Resources {
+		if unit.CPU != nil && match(unit.CPU.Attributes) {
+			return true
+		}
+		if unit.Memory != nil && match(unit.Memory.Attributes) {
+			return true
+		}
+		if unit.GPU != nil && match(unit.GPU.Attributes) {
+			return true
+		}
+		for _, vol := range unit.Storage {
+			if match(vol.Attributes) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
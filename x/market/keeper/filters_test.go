@@ -0,0 +1,220 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
+	"github.com/stretchr/testify/require"
+
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	atypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+
+	"github.com/akash-network/node/testutil"
+	"github.com/akash-network/node/x/market/keeper"
+)
+
+func Test_FilterBids(t *testing.T) {
+	ctx, mkeeper, _ := setupKeeper(t)
+
+	provider := testutil.AccAddress(t)
+	other := testutil.AccAddress(t)
+
+	var bids []types.Bid
+
+	for i := int64(0); i < 4; i++ {
+		ctx := ctx.WithBlockHeight(10 * (i + 1))
+
+		group := testutil.DeploymentGroup(t, testutil.DeploymentID(t), 0)
+		group.GroupSpec.Requirements.Attributes = nil
+		if i == 3 {
+			group.GroupSpec.Requirements.Attributes = atypes.Attributes{{Key: "tier", Value: "premium"}}
+		}
+
+		order, err := mkeeper.CreateOrder(ctx, group.ID(), group.GroupSpec)
+		require.NoError(t, err)
+
+		roffer := types.ResourceOfferFromRU(group.GroupSpec.Resources)
+
+		bid, err := mkeeper.CreateBid(ctx, order.ID(), provider, sdk.NewDecCoin("uakt", sdk.NewInt(10+i)), roffer)
+		require.NoError(t, err)
+		bids = append(bids, bid)
+
+		_, err = mkeeper.CreateBid(ctx, order.ID(), other, sdk.NewDecCoin("uakt", sdk.NewInt(10+i)), roffer)
+		require.NoError(t, err)
+	}
+
+	mkeeper.OnBidLost(ctx, bids[0])
+
+	filter := func(filters keeper.BidQueryFilters) []types.BidID {
+		res, _, err := mkeeper.FilterBids(ctx, filters, nil)
+		require.NoError(t, err)
+
+		ids := make([]types.BidID, 0, len(res))
+		for _, bid := range res {
+			ids = append(ids, bid.ID())
+		}
+		return ids
+	}
+
+	byProvider := types.BidFilters{Provider: provider.String(), State: types.BidOpen.String()}
+
+	require.ElementsMatch(t, []types.BidID{bids[1].ID(), bids[2].ID(), bids[3].ID()},
+		filter(keeper.BidQueryFilters{BidFilters: byProvider}))
+
+	// lost bids are not in the provider index
+	lost := byProvider
+	lost.State = types.BidLost.String()
+	_, _, err := mkeeper.FilterBids(ctx, keeper.BidQueryFilters{BidFilters: lost}, nil)
+	require.Error(t, err)
+
+	_, _, err = mkeeper.FilterBids(ctx, keeper.BidQueryFilters{BidFilters: types.BidFilters{Provider: provider.String()}}, nil)
+	require.Error(t, err)
+
+	// bids of the owner are found in any state
+	require.Equal(t, []types.BidID{bids[0].ID()}, filter(keeper.BidQueryFilters{BidFilters: types.BidFilters{
+		Owner:    bids[0].ID().Owner,
+		Provider: provider.String(),
+	}}))
+
+	require.ElementsMatch(t, []types.BidID{bids[1].ID(), bids[2].ID()}, filter(keeper.BidQueryFilters{
+		BidFilters: byProvider,
+		CreatedAt:  keeper.HeightRange{From: 20, To: 30},
+	}))
+
+	require.ElementsMatch(t, []types.BidID{bids[2].ID(), bids[3].ID()}, filter(keeper.BidQueryFilters{
+		BidFilters: byProvider,
+		Price:      keeper.PriceRange{Denom: "uakt", Min: sdk.NewDec(12)},
+	}))
+
+	require.Empty(t, filter(keeper.BidQueryFilters{
+		BidFilters: byProvider,
+		Price:      keeper.PriceRange{Denom: "uusdc"},
+	}))
+
+	require.Equal(t, []types.BidID{bids[3].ID()}, filter(keeper.BidQueryFilters{
+		BidFilters: byProvider,
+		Order:      keeper.OrderPredicate{Attributes: atypes.Attributes{{Key: "tier"}}},
+	}))
+
+	require.Empty(t, filter(keeper.BidQueryFilters{
+		BidFilters: byProvider,
+		Order:      keeper.OrderPredicate{Attributes: atypes.Attributes{{Key: "tier", Value: "community"}}},
+	}))
+
+	require.Empty(t, filter(keeper.BidQueryFilters{
+		BidFilters: byProvider,
+		Order:      keeper.OrderPredicate{GPU: 1 << 32},
+	}))
+
+	_, _, err = mkeeper.FilterBids(ctx, keeper.BidQueryFilters{BidFilters: types.BidFilters{State: "unknown"}}, nil)
+	require.Error(t, err)
+}
+
+func Test_FilterBidsPagination(t *testing.T) {
+	ctx, mkeeper, _ := setupKeeper(t)
+
+	provider := testutil.AccAddress(t)
+
+	var ids []types.BidID
+
+	for i := 0; i < 3; i++ {
+		group := testutil.DeploymentGroup(t, testutil.DeploymentID(t), 0)
+
+		order, err := mkeeper.CreateOrder(ctx, group.ID(), group.GroupSpec)
+		require.NoError(t, err)
+
+		bid, err := mkeeper.CreateBid(ctx, order.ID(), provider, testutil.AkashDecCoin(t, 10), types.ResourceOfferFromRU(group.GroupSpec.Resources))
+		require.NoError(t, err)
+		ids = append(ids, bid.ID())
+	}
+
+	filters := keeper.BidQueryFilters{BidFilters: types.BidFilters{Provider: provider.String(), State: types.BidOpen.String()}}
+
+	var res []types.BidID
+	pageReq := &sdkquery.PageRequest{Limit: 1}
+
+	for {
+		bids, pageRes, err := mkeeper.FilterBids(ctx, filters, pageReq)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(bids), 1)
+
+		for _, bid := range bids {
+			res = append(res, bid.ID())
+		}
+
+		if len(pageRes.NextKey) == 0 {
+			break
+		}
+
+		pageReq.Key = pageRes.NextKey
+	}
+
+	require.ElementsMatch(t, ids, res)
+
+	_, _, err := mkeeper.FilterBids(ctx, filters, &sdkquery.PageRequest{Key: []byte("invalid")})
+	require.Error(t, err)
+
+	_, _, err = mkeeper.FilterBids(ctx, filters, &sdkquery.PageRequest{Limit: keeper.MaxFilterLimit + 1})
+	require.Error(t, err)
+
+	_, _, err = mkeeper.FilterBids(ctx, filters, &sdkquery.PageRequest{Offset: 1})
+	require.Error(t, err)
+}
+
+func Test_FilterLeases(t *testing.T) {
+	ctx, mkeeper, suite := setupKeeper(t)
+
+	id := createLease(t, suite)
+	createLease(t, suite)
+
+	lease, found := mkeeper.GetLease(ctx, id)
+	require.True(t, found)
+
+	filter := func(filters keeper.LeaseQueryFilters) []types.LeaseID {
+		res, _, err := mkeeper.FilterLeases(ctx, filters, nil)
+		require.NoError(t, err)
+
+		ids := make([]types.LeaseID, 0, len(res))
+		for _, lease := range res {
+			ids = append(ids, lease.ID())
+		}
+		return ids
+	}
+
+	byProvider := types.LeaseFilters{Provider: id.Provider, State: types.LeaseActive.String()}
+
+	require.Equal(t, []types.LeaseID{id}, filter(keeper.LeaseQueryFilters{LeaseFilters: byProvider}))
+
+	require.Equal(t, []types.LeaseID{id}, filter(keeper.LeaseQueryFilters{
+		LeaseFilters: byProvider,
+		CreatedAt:    keeper.HeightRange{From: lease.CreatedAt},
+		Price:        keeper.PriceRange{Denom: lease.Price.Denom, Min: lease.Price.Amount, Max: lease.Price.Amount},
+	}))
+
+	require.Empty(t, filter(keeper.LeaseQueryFilters{
+		LeaseFilters: byProvider,
+		CreatedAt:    keeper.HeightRange{From: lease.CreatedAt + 1},
+	}))
+
+	require.Empty(t, filter(keeper.LeaseQueryFilters{
+		LeaseFilters: byProvider,
+		Price:        keeper.PriceRange{Max: lease.Price.Amount.Sub(sdk.SmallestDec())},
+	}))
+
+	mkeeper.OnLeaseClosed(ctx, lease, types.LeaseClosed)
+
+	require.Empty(t, filter(keeper.LeaseQueryFilters{LeaseFilters: byProvider}))
+
+	// closed leases are not in the provider index
+	_, _, err := mkeeper.FilterLeases(ctx, keeper.LeaseQueryFilters{LeaseFilters: types.LeaseFilters{
+		Provider: id.Provider,
+		State:    types.LeaseClosed.String(),
+	}}, nil)
+	require.Error(t, err)
+
+	require.Equal(t, []types.LeaseID{id}, filter(keeper.LeaseQueryFilters{LeaseFilters: types.LeaseFilters{
+		Owner:    id.Owner,
+		Provider: id.Provider,
+	}}))
+}
@@ -0,0 +1,242 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+)
+
+const (
+	invariantLeaseState = "lease-state"
+	invariantBidState   = "bid-state"
+	invariantOrderState = "order-state"
+	invariantEscrow     = "escrow"
+)
+
+// RegisterInvariants registers the market module invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k IKeeper, dk DeploymentKeeper, ek EscrowKeeper) {
+	ir.RegisterRoute(types.ModuleName, invariantLeaseState, LeaseStateInvariant(k))
+	ir.RegisterRoute(types.ModuleName, invariantBidState, BidStateInvariant(k))
+	ir.RegisterRoute(types.ModuleName, invariantOrderState, OrderStateInvariant(k, dk))
+	ir.RegisterRoute(types.ModuleName, invariantEscrow, EscrowInvariant(k, ek))
+}
+
+// AllInvariants runs all invariants of the market module
+func AllInvariants(k IKeeper, dk DeploymentKeeper, ek EscrowKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		for _, inv := range []sdk.Invariant{
+			LeaseStateInvariant(k),
+			BidStateInvariant(k),
+			OrderStateInvariant(k, dk),
+			EscrowInvariant(k, ek),
+		} {
+			if res, stop := inv(ctx); stop {
+				return res, stop
+			}
+		}
+
+		return "", false
+	}
+}
+
+// LeaseStateInvariant checks that bid and order of every active lease are active
+// and that bid of a closed lease is not active anymore
+func LeaseStateInvariant(k IKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		k.WithLeases(ctx, func(lease types.Lease) bool {
+			bid, found := k.GetBid(ctx, lease.ID().BidID())
+			if !found {
+				count++
+				msg += fmt.Sprintf("\tlease %s has no bid\n", lease.ID())
+				return false
+			}
+
+			order, found := k.GetOrder(ctx, lease.ID().OrderID())
+			if !found {
+				count++
+				msg += fmt.Sprintf("\tlease %s has no order\n", lease.ID())
+				return false
+			}
+
+			if lease.State == types.LeaseActive {
+				if bid.State != types.BidActive {
+					count++
+					msg += fmt.Sprintf("\tactive lease %s has bid in state %s\n", lease.ID(), bid.State)
+				}
+
+				if order.State != types.OrderActive {
+					count++
+					msg += fmt.Sprintf("\tactive lease %s has order in state %s\n", lease.ID(), order.State)
+				}
+			} else if bid.State == types.BidActive {
+				count++
+				msg += fmt.Sprintf("\tlease %s in state %s has active bid\n", lease.ID(), lease.State)
+			}
+
+			return false
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, invariantLeaseState,
+			fmt.Sprintf("amount of inconsistent leases found %d\n%s", count, msg)), broken
+	}
+}
+
+// BidStateInvariant checks that every active bid has an active lease
+// and that every open bid belongs to an open order
+func BidStateInvariant(k IKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		k.WithBids(ctx, func(bid types.Bid) bool {
+			switch bid.State {
+			case types.BidActive:
+				lease, found := k.GetLease(ctx, bid.ID().LeaseID())
+				if !found || lease.State != types.LeaseActive {
+					count++
+					msg += fmt.Sprintf("\tactive bid %s has no active lease\n", bid.ID())
+				}
+			case types.BidOpen:
+				order, found := k.GetOrder(ctx, bid.ID().OrderID())
+				if !found || order.State != types.OrderOpen {
+					count++
+					msg += fmt.Sprintf("\topen bid %s has no open order\n", bid.ID())
+				}
+			}
+
+			return false
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, invariantBidState,
+			fmt.Sprintf("amount of inconsistent bids found %d\n%s", count, msg)), broken
+	}
+}
+
+// OrderStateInvariant checks that every open or active order belongs to an open group,
+// that group has no other open or active order and every active order has exactly one active bid
+func OrderStateInvariant(k IKeeper, dk DeploymentKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		k.WithOrders(ctx, func(order types.Order) bool {
+			if order.State == types.OrderClosed {
+				return false
+			}
+
+			group, found := dk.GetGroup(ctx, order.ID().GroupID())
+			if !found {
+				count++
+				msg += fmt.Sprintf("\torder %s in state %s has no group\n", order.ID(), order.State)
+				return false
+			}
+
+			if group.State != dtypes.GroupOpen {
+				count++
+				msg += fmt.Sprintf("\torder %s in state %s belongs to group in state %s\n", order.ID(), order.State, group.State)
+			}
+
+			orders := 0
+			for _, state := range []types.Order_State{types.OrderOpen, types.OrderActive} {
+				k.WithOrdersForGroup(ctx, group.ID(), state, func(types.Order) bool {
+					orders++
+					return false
+				})
+			}
+
+			if orders != 1 {
+				count++
+				msg += fmt.Sprintf("\torder %s belongs to group with %d open or active orders\n", order.ID(), orders)
+			}
+
+			if order.State == types.OrderActive {
+				bids := 0
+				k.WithBidsForOrder(ctx, order.ID(), types.BidActive, func(types.Bid) bool {
+					bids++
+					return false
+				})
+
+				if bids != 1 {
+					count++
+					msg += fmt.Sprintf("\tactive order %s has %d active bids\n", order.ID(), bids)
+				}
+			}
+
+			return false
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, invariantOrderState,
+			fmt.Sprintf("amount of inconsistent orders found %d\n%s", count, msg)), broken
+	}
+}
+
+// EscrowInvariant checks that every active lease has an open escrow payment in an open
+// deployment escrow account and every open or active bid has an open escrow account
+func EscrowInvariant(k IKeeper, ek EscrowKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		k.WithLeases(ctx, func(lease types.Lease) bool {
+			if lease.State != types.LeaseActive {
+				return false
+			}
+
+			aid := dtypes.EscrowAccountForDeployment(lease.ID().DeploymentID())
+
+			account, err := ek.GetAccount(ctx, aid)
+			if err != nil || account.State != etypes.AccountOpen {
+				count++
+				msg += fmt.Sprintf("\tactive lease %s has no open escrow account\n", lease.ID())
+			}
+
+			payment, err := ek.GetPayment(ctx, aid, types.EscrowPaymentForLease(lease.ID()))
+			if err != nil || payment.State != etypes.PaymentOpen {
+				count++
+				msg += fmt.Sprintf("\tactive lease %s has no open escrow payment\n", lease.ID())
+			}
+
+			return false
+		})
+
+		k.WithBids(ctx, func(bid types.Bid) bool {
+			if bid.State != types.BidOpen && bid.State != types.BidActive {
+				return false
+			}
+
+			account, err := ek.GetAccount(ctx, types.EscrowAccountForBid(bid.ID()))
+			if err != nil || account.State != etypes.AccountOpen {
+				count++
+				msg += fmt.Sprintf("\tbid %s in state %s has no open escrow account\n", bid.ID(), bid.State)
+			}
+
+			return false
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, invariantEscrow,
+			fmt.Sprintf("amount of inconsistent escrow accounts and payments found %d\n%s", count, msg)), broken
+	}
+}
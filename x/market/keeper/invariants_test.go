@@ -0,0 +1,58 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	"github.com/akash-network/node/x/market/keeper"
+)
+
+func Test_Invariants(t *testing.T) {
+	ctx, mkeeper, suite := setupKeeper(t)
+	ekeeper := suite.EscrowKeeper()
+
+	id := createLease(t, suite)
+
+	_, broken := keeper.LeaseStateInvariant(mkeeper)(ctx)
+	require.False(t, broken)
+
+	_, broken = keeper.BidStateInvariant(mkeeper)(ctx)
+	require.False(t, broken)
+
+	_, broken = keeper.EscrowInvariant(mkeeper, ekeeper)(ctx)
+	require.False(t, broken)
+
+	// closing lease without its bid breaks lease and bid state invariants
+	lease, found := mkeeper.GetLease(ctx, id)
+	require.True(t, found)
+
+	mkeeper.OnLeaseClosed(ctx, lease, types.LeaseClosed)
+
+	_, broken = keeper.LeaseStateInvariant(mkeeper)(ctx)
+	require.True(t, broken)
+
+	_, broken = keeper.BidStateInvariant(mkeeper)(ctx)
+	require.True(t, broken)
+}
+
+func Test_EscrowInvariant(t *testing.T) {
+	ctx, mkeeper, suite := setupKeeper(t)
+	ekeeper := suite.EscrowKeeper()
+
+	id := createLease(t, suite)
+
+	// active lease with closed escrow payment breaks the invariant
+	payment, err := ekeeper.GetPayment(ctx, dtypes.EscrowAccountForDeployment(id.DeploymentID()), types.EscrowPaymentForLease(id))
+	require.NoError(t, err)
+
+	payment.State = etypes.PaymentClosed
+	ekeeper.SavePayment(ctx, payment)
+
+	_, broken := keeper.EscrowInvariant(mkeeper, ekeeper)(ctx)
+	require.True(t, broken)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
 	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
 
 	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
@@ -34,10 +35,20 @@ type IKeeper interface {
 	WithOrders(ctx sdk.Context, fn func(types.Order) bool)
 	WithBids(ctx sdk.Context, fn func(types.Bid) bool)
 	WithLeases(ctx sdk.Context, fn func(types.Lease) bool)
+	WithOrdersForGroup(ctx sdk.Context, id dtypes.GroupID, state types.Order_State, fn func(types.Order) bool)
 	WithBidsForOrder(ctx sdk.Context, id types.OrderID, state types.Bid_State, fn func(types.Bid) bool)
+	WithBidsForProvider(ctx sdk.Context, provider sdk.AccAddress, state types.Bid_State, fn func(types.Bid) bool)
 	BidCountForOrder(ctx sdk.Context, id types.OrderID) uint32
+	FilterBids(ctx sdk.Context, filters BidQueryFilters, pageReq *sdkquery.PageRequest) ([]types.Bid, *sdkquery.PageResponse, error)
+	FilterLeases(ctx sdk.Context, filters LeaseQueryFilters, pageReq *sdkquery.PageRequest) ([]types.Lease, *sdkquery.PageResponse, error)
+	PriceStats(ctx sdk.Context) (MarketPriceStats, error)
+	RebuildPriceIndex(ctx sdk.Context)
+	WithExpiredOrders(ctx sdk.Context, height int64, fn func(types.Order) bool)
+	WithExpiredBids(ctx sdk.Context, height int64, fn func(types.Bid) bool)
 	GetParams(ctx sdk.Context) (params types.Params)
 	SetParams(ctx sdk.Context, params types.Params)
+	GetExpirationParams(ctx sdk.Context) ExpirationParams
+	SetExpirationParams(ctx sdk.Context, params ExpirationParams)
 }
 
 // Keeper of the market store
@@ -51,7 +62,7 @@ type Keeper struct {
 // NewKeeper creates and returns an instance for Market keeper
 func NewKeeper(cdc codec.BinaryCodec, skey sdk.StoreKey, pspace paramtypes.Subspace, ekeeper EscrowKeeper) IKeeper {
 	if !pspace.HasKeyTable() {
-		pspace = pspace.WithKeyTable(types.ParamKeyTable())
+		pspace = pspace.WithKeyTable(ParamKeyTable())
 	}
 
 	return Keeper{
@@ -118,6 +129,7 @@ func (k Keeper) CreateOrder(ctx sdk.Context, gid dtypes.GroupID, spec dtypes.Gro
 	key := keys.MustOrderKey(keys.OrderStateOpenPrefix, order.ID())
 
 	store.Set(key, k.cdc.MustMarshal(&order))
+	store.Set(keys.OrderOpenHeightKey(order.CreatedAt, order.ID()), key)
 
 	ctx.Logger().Info("created order", "order", order.ID())
 
@@ -152,11 +164,14 @@ func (k Keeper) CreateBid(ctx sdk.Context, oid types.OrderID, provider sdk.AccAd
 	revKey := keys.MustBidStateRevereKey(bid.State, bidID)
 
 	store.Set(key, data)
+	store.Set(keys.BidOpenHeightKey(bid.CreatedAt, bidID), key)
 
 	if len(revKey) > 0 {
 		store.Set(revKey, data)
 	}
 
+	k.indexBidPrice(ctx, bid)
+
 	ctx.EventManager().EmitEvent(
 		types.NewEventBidCreated(bid.ID(), price).
 			ToSDKEvent(),
@@ -188,6 +203,8 @@ func (k Keeper) CreateLease(ctx sdk.Context, bid types.Bid) {
 		store.Set(revKey, data)
 	}
 
+	k.indexLeasePrice(ctx, lease)
+
 	ctx.Logger().Info("created lease", "lease", lease.ID())
 	ctx.EventManager().EmitEvent(
 		types.NewEventLeaseCreated(lease.ID(), lease.Price).
@@ -276,6 +293,8 @@ func (k Keeper) OnLeaseClosed(ctx sdk.Context, lease types.Lease, state types.Le
 	key = keys.MustLeaseKey(keys.LeaseStateToPrefix(lease.State), lease.ID())
 	store.Set(key, k.cdc.MustMarshal(&lease))
 
+	k.unindexLeasePrice(ctx, lease)
+
 	ctx.EventManager().EmitEvent(
 		types.NewEventLeaseClosed(lease.ID(), lease.Price).
 			ToSDKEvent(),
@@ -298,21 +317,24 @@ func (k Keeper) OnGroupClosed(ctx sdk.Context, id dtypes.GroupID) {
 		}
 	}
 
-	k.WithOrdersForGroup(ctx, id, types.OrderActive, func(order types.Order) bool {
-		k.OnOrderClosed(ctx, order)
+	// open orders are closed too, otherwise they outlive their group
+	for _, state := range []types.Order_State{types.OrderActive, types.OrderOpen} {
+		k.WithOrdersForGroup(ctx, id, state, func(order types.Order) bool {
+			k.OnOrderClosed(ctx, order)
 
-		k.WithBidsForOrder(ctx, order.ID(), types.BidOpen, func(bid types.Bid) bool {
-			processClose(ctx, bid)
-			return false
-		})
+			k.WithBidsForOrder(ctx, order.ID(), types.BidOpen, func(bid types.Bid) bool {
+				processClose(ctx, bid)
+				return false
+			})
+
+			k.WithBidsForOrder(ctx, order.ID(), types.BidActive, func(bid types.Bid) bool {
+				processClose(ctx, bid)
+				return false
+			})
 
-		k.WithBidsForOrder(ctx, order.ID(), types.BidActive, func(bid types.Bid) bool {
-			processClose(ctx, bid)
 			return false
 		})
-
-		return false
-	})
+	}
 }
 
 func (k Keeper) findOrder(ctx sdk.Context, id types.OrderID) []byte {
@@ -522,6 +544,39 @@ func (k Keeper) WithBidsForOrder(ctx sdk.Context, id types.OrderID, state types.
 	}
 }
 
+// WithBidsForProvider iterates all bids of a provider in market with given state.
+// Only open and active bids are indexed by provider.
+func (k Keeper) WithBidsForProvider(ctx sdk.Context, provider sdk.AccAddress, state types.Bid_State, fn func(types.Bid) bool) {
+	switch state {
+	case types.BidOpen, types.BidActive:
+	default:
+		return
+	}
+
+	prefix, err := keys.BidReversePrefixFromFilter(types.BidFilters{
+		Provider: provider.String(),
+		State:    state.String(),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	store := ctx.KVStore(k.skey)
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for ; iter.Valid(); iter.Next() {
+		var val types.Bid
+		k.cdc.MustUnmarshal(iter.Value(), &val)
+		if stop := fn(val); stop {
+			break
+		}
+	}
+}
+
 func (k Keeper) BidCountForOrder(ctx sdk.Context, id types.OrderID) uint32 {
 	store := ctx.KVStore(k.skey)
 	oiter := sdk.KVStorePrefixIterator(store, keys.BidsForOrderPrefix(keys.BidStateOpenPrefix, id))
@@ -561,6 +616,62 @@ func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	k.pspace.SetParamSet(ctx, &params)
 }
 
+// GetExpirationParams returns order and bid expiration parameters.
+func (k Keeper) GetExpirationParams(ctx sdk.Context) ExpirationParams {
+	params := DefaultExpirationParams()
+	k.pspace.GetParamSetIfExists(ctx, &params)
+	return params
+}
+
+// SetExpirationParams sets order and bid expiration parameters to the paramspace.
+func (k Keeper) SetExpirationParams(ctx sdk.Context, params ExpirationParams) {
+	k.pspace.SetParamSet(ctx, &params)
+}
+
+// WithExpiredOrders iterates open orders created at or below given height, oldest first
+func (k Keeper) WithExpiredOrders(ctx sdk.Context, height int64, fn func(types.Order) bool) {
+	if height < 0 {
+		return
+	}
+
+	store := ctx.KVStore(k.skey)
+	iter := store.Iterator(keys.OrderOpenHeightPrefix, keys.OrderOpenHeightEndKey(height))
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for ; iter.Valid(); iter.Next() {
+		var val types.Order
+		k.cdc.MustUnmarshal(store.Get(iter.Value()), &val)
+		if stop := fn(val); stop {
+			break
+		}
+	}
+}
+
+// WithExpiredBids iterates open bids created at or below given height, oldest first
+func (k Keeper) WithExpiredBids(ctx sdk.Context, height int64, fn func(types.Bid) bool) {
+	if height < 0 {
+		return
+	}
+
+	store := ctx.KVStore(k.skey)
+	iter := store.Iterator(keys.BidOpenHeightPrefix, keys.BidOpenHeightEndKey(height))
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for ; iter.Valid(); iter.Next() {
+		var val types.Bid
+		k.cdc.MustUnmarshal(store.Get(iter.Value()), &val)
+		if stop := fn(val); stop {
+			break
+		}
+	}
+}
+
 func (k Keeper) updateOrder(ctx sdk.Context, order types.Order, currState types.Order_State) {
 	store := ctx.KVStore(k.skey)
 
@@ -574,6 +685,10 @@ func (k Keeper) updateOrder(ctx sdk.Context, order types.Order, currState types.
 	key := keys.MustOrderKey(keys.OrderStateToPrefix(currState), order.ID())
 	store.Delete(key)
 
+	if currState == types.OrderOpen {
+		store.Delete(keys.OrderOpenHeightKey(order.CreatedAt, order.ID()))
+	}
+
 	switch order.State {
 	case types.OrderActive:
 	case types.OrderClosed:
@@ -604,6 +719,11 @@ func (k Keeper) updateBid(ctx sdk.Context, bid types.Bid, currState types.Bid_St
 		store.Delete(revKey)
 	}
 
+	if currState == types.BidOpen {
+		store.Delete(keys.BidOpenHeightKey(bid.CreatedAt, bid.ID()))
+		k.unindexBidPrice(ctx, bid)
+	}
+
 	switch bid.State {
 	case types.BidActive:
 	case types.BidLost:
@@ -13,6 +13,7 @@ import (
 
 	"github.com/akash-network/node/testutil"
 	"github.com/akash-network/node/testutil/state"
+	"github.com/akash-network/node/x/market"
 	"github.com/akash-network/node/x/market/keeper"
 )
 
@@ -257,6 +258,104 @@ func Test_OnGroupClosed(t *testing.T) {
 	assert.Equal(t, types.OrderClosed, order.State)
 }
 
+func Test_OnGroupClosed_OpenOrder(t *testing.T) {
+	_, keeper, suite := setupKeeper(t)
+	bid, order := createBid(t, suite)
+
+	keeper.OnGroupClosed(suite.Context(), order.ID().GroupID())
+
+	result, ok := keeper.GetOrder(suite.Context(), order.ID())
+	require.True(t, ok)
+	assert.Equal(t, types.OrderClosed, result.State)
+
+	rbid, ok := keeper.GetBid(suite.Context(), bid.ID())
+	require.True(t, ok)
+	assert.Equal(t, types.BidClosed, rbid.State)
+}
+
+func Test_WithExpiredOrders(t *testing.T) {
+	_, keeper, suite := setupKeeper(t)
+
+	suite.SetBlockHeight(10)
+	order, _ := createOrder(t, suite.Context(), keeper)
+
+	suite.SetBlockHeight(20)
+	createOrder(t, suite.Context(), keeper)
+
+	var expired []types.OrderID
+	keeper.WithExpiredOrders(suite.Context(), 15, func(result types.Order) bool {
+		expired = append(expired, result.ID())
+		return false
+	})
+	require.Equal(t, []types.OrderID{order.ID()}, expired)
+
+	keeper.OnOrderMatched(suite.Context(), order)
+
+	count := 0
+	keeper.WithExpiredOrders(suite.Context(), 20, func(result types.Order) bool {
+		assert.NotEqual(t, order.ID(), result.ID())
+		count++
+		return false
+	})
+	assert.Equal(t, 1, count)
+}
+
+func Test_WithExpiredBids(t *testing.T) {
+	_, keeper, suite := setupKeeper(t)
+
+	suite.SetBlockHeight(10)
+	bid, _ := createBid(t, suite)
+
+	suite.SetBlockHeight(20)
+	other, _ := createBid(t, suite)
+
+	var expired []types.BidID
+	keeper.WithExpiredBids(suite.Context(), 15, func(result types.Bid) bool {
+		expired = append(expired, result.ID())
+		return false
+	})
+	require.Equal(t, []types.BidID{bid.ID()}, expired)
+
+	keeper.OnBidClosed(suite.Context(), bid)
+
+	expired = nil
+	keeper.WithExpiredBids(suite.Context(), 20, func(result types.Bid) bool {
+		expired = append(expired, result.ID())
+		return false
+	})
+	require.Equal(t, []types.BidID{other.ID()}, expired)
+}
+
+func Test_ExpirationParamsGenesis(t *testing.T) {
+	ctx, mkeeper, suite := setupKeeper(t)
+	cdc := suite.App().AppCodec()
+
+	params := keeper.ExpirationParams{
+		OrderTTL:              200,
+		BidTTL:                50,
+		ExpirationBlockBudget: 7,
+	}
+	mkeeper.SetExpirationParams(ctx, params)
+
+	bz, err := market.MarshalGenesis(cdc, market.ExportGenesis(ctx, mkeeper))
+	require.NoError(t, err)
+
+	data, err := market.UnmarshalGenesis(cdc, bz)
+	require.NoError(t, err)
+	require.NoError(t, market.ValidateGenesis(data))
+
+	imported := state.SetupTestSuite(t)
+	market.InitGenesis(imported.Context(), imported.MarketKeeper(), data)
+
+	require.Equal(t, params, imported.MarketKeeper().GetExpirationParams(imported.Context()))
+
+	// genesis of the API carries no expiration params
+	data, err = market.UnmarshalGenesis(cdc, cdc.MustMarshalJSON(&types.GenesisState{Params: types.DefaultParams()}))
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultParams(), data.Params)
+	require.Equal(t, keeper.DefaultExpirationParams(), data.ExpirationParams)
+}
+
 func createLease(t testing.TB, suite *state.TestSuite) types.LeaseID {
 	t.Helper()
 	ctx := suite.Context()
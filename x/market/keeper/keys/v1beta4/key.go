@@ -2,7 +2,11 @@ package v1beta4
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/big"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/address"
@@ -23,6 +27,8 @@ const (
 	LeaseStateActivePrefixID            = byte(0x01)
 	LeaseStateInsufficientFundsPrefixID = byte(0x02)
 	LeaseStateClosedPrefixID            = byte(0x03)
+	PriceIndexLeasePrefixID             = byte(0x01)
+	PriceIndexBidPrefixID               = byte(0x02)
 )
 
 var (
@@ -30,19 +36,35 @@ var (
 	OrderStateOpenPrefix              = []byte{OrderStateOpenPrefixID}
 	OrderStateActivePrefix            = []byte{OrderStateActivePrefixID}
 	OrderStateClosedPrefix            = []byte{OrderStateClosedPrefixID}
+	OrderOpenHeightPrefix             = []byte{0x11, 0x02}
 	BidPrefix                         = []byte{0x12, 0x00}
 	BidPrefixReverse                  = []byte{0x12, 0x01}
 	BidStateOpenPrefix                = []byte{BidStateOpenPrefixID}
 	BidStateActivePrefix              = []byte{BidStateActivePrefixID}
 	BidStateLostPrefix                = []byte{BidStateLostPrefixID}
 	BidStateClosedPrefix              = []byte{BidStateClosedPrefixID}
+	BidOpenHeightPrefix               = []byte{0x12, 0x02}
 	LeasePrefix                       = []byte{0x13, 0x00}
 	LeasePrefixReverse                = []byte{0x13, 0x01}
 	LeaseStateActivePrefix            = []byte{LeaseStateActivePrefixID}
 	LeaseStateInsufficientFundsPrefix = []byte{LeaseStateInsufficientFundsPrefixID}
 	LeaseStateClosedPrefix            = []byte{LeaseStateClosedPrefixID}
+	PriceIndexPrefix                  = []byte{0x14, 0x00}
+	PriceCountPrefix                  = []byte{0x14, 0x01}
 )
 
+var (
+	ErrInvalidPriceIndexKey = errors.New("invalid price index key")
+)
+
+// priceLen is length of price encoded in price index keys.
+// sdk.Dec is at most 315 bits long
+const priceLen = 40
+
+// MaxPriceBucketLen is maximum length of bucket encoded in price index keys.
+// Longer buckets are truncated and suffixed with their hash to keep them distinct
+const MaxPriceBucketLen = 256
+
 func OrderKey(statePrefix []byte, id types.OrderID) ([]byte, error) {
 	owner, err := sdk.AccAddressFromBech32(id.Owner)
 	if err != nil {
@@ -318,6 +340,141 @@ func MustLeaseReverseKey(statePrefix []byte, id types.LeaseID) []byte {
 	return key
 }
 
+// OrderOpenHeightKey returns key of the open order index entry ordered by order creation height
+func OrderOpenHeightKey(height int64, id types.OrderID) []byte {
+	return openHeightKey(OrderOpenHeightPrefix, height, MustOrderKey(nil, id)[len(OrderPrefix):])
+}
+
+// BidOpenHeightKey returns key of the open bid index entry ordered by bid creation height
+func BidOpenHeightKey(height int64, id types.BidID) []byte {
+	return openHeightKey(BidOpenHeightPrefix, height, MustBidKey(nil, id)[len(BidPrefix):])
+}
+
+// OrderOpenHeightEndKey returns exclusive upper bound of open orders created at or below given height
+func OrderOpenHeightEndKey(height int64) []byte {
+	return openHeightKey(OrderOpenHeightPrefix, height+1, nil)
+}
+
+// BidOpenHeightEndKey returns exclusive upper bound of open bids created at or below given height
+func BidOpenHeightEndKey(height int64) []byte {
+	return openHeightKey(BidOpenHeightPrefix, height+1, nil)
+}
+
+// PriceIndexKey returns key of the price index entry of resource unit with given index
+// of lease or bid id. Entries are ordered by bucket, denomination and price
+func PriceIndexKey(source byte, bucket string, price sdk.DecCoin, unit uint32, id []byte) []byte {
+	buf := bytes.NewBuffer(PriceIndexPrefixFor(source, bucket, price.Denom))
+	buf.Write(price.Amount.BigInt().FillBytes(make([]byte, priceLen)))
+
+	if err := binary.Write(buf, binary.BigEndian, unit); err != nil {
+		panic(err)
+	}
+
+	buf.Write(id)
+
+	return buf.Bytes()
+}
+
+// PriceIndexPrefixFor returns prefix of price index entries of given source, bucket and denomination.
+// Empty bucket returns prefix of all entries of the source, empty denom prefix of all entries of the bucket
+func PriceIndexPrefixFor(source byte, bucket string, denom string) []byte {
+	buf := bytes.NewBuffer(PriceIndexPrefix)
+	buf.WriteByte(source)
+
+	if bucket == "" {
+		return buf.Bytes()
+	}
+
+	bucket = priceBucket(bucket)
+
+	if err := binary.Write(buf, binary.BigEndian, uint16(len(bucket))); err != nil { // nolint: gosec
+		panic(err)
+	}
+	buf.WriteString(bucket)
+
+	if denom == "" {
+		return buf.Bytes()
+	}
+
+	buf.WriteByte(byte(len(denom)))
+	buf.WriteString(denom)
+
+	return buf.Bytes()
+}
+
+// PriceCountKey returns key of the number of price index entries of given source, bucket and denomination.
+// Empty bucket returns prefix of counts of all entries of the source
+func PriceCountKey(source byte, bucket string, denom string) []byte {
+	return swapPrefix(PriceIndexPrefixFor(source, bucket, denom), PriceIndexPrefix, PriceCountPrefix)
+}
+
+// PriceIndexPrefixFromCountKey returns prefix of price index entries counted by given count key
+func PriceIndexPrefixFromCountKey(key []byte) []byte {
+	return swapPrefix(key, PriceCountPrefix, PriceIndexPrefix)
+}
+
+func swapPrefix(key []byte, from []byte, to []byte) []byte {
+	res := make([]byte, 0, len(to)+len(key)-len(from))
+	res = append(res, to...)
+
+	return append(res, key[len(from):]...)
+}
+
+// priceBucket bounds length of the bucket to MaxPriceBucketLen
+func priceBucket(bucket string) string {
+	if len(bucket) <= MaxPriceBucketLen {
+		return bucket
+	}
+
+	sum := sha256.Sum256([]byte(bucket))
+	digest := hex.EncodeToString(sum[:])
+
+	return bucket[:MaxPriceBucketLen-len(digest)-1] + "#" + digest
+}
+
+// ParsePriceIndexKey returns bucket and price of the price index entry
+func ParsePriceIndexKey(key []byte) (string, sdk.DecCoin, error) {
+	if !bytes.HasPrefix(key, PriceIndexPrefix) || len(key) < len(PriceIndexPrefix)+3 {
+		return "", sdk.DecCoin{}, ErrInvalidPriceIndexKey
+	}
+
+	key = key[len(PriceIndexPrefix)+1:]
+
+	blen := int(binary.BigEndian.Uint16(key))
+	key = key[2:]
+
+	if len(key) < blen+1 {
+		return "", sdk.DecCoin{}, ErrInvalidPriceIndexKey
+	}
+
+	bucket := string(key[:blen])
+	key = key[blen:]
+
+	dlen := int(key[0])
+	key = key[1:]
+
+	if len(key) < dlen+priceLen {
+		return "", sdk.DecCoin{}, ErrInvalidPriceIndexKey
+	}
+
+	denom := string(key[:dlen])
+	amount := sdk.NewDecFromBigIntWithPrec(new(big.Int).SetBytes(key[dlen:dlen+priceLen]), sdk.Precision)
+
+	return bucket, sdk.DecCoin{Denom: denom, Amount: amount}, nil
+}
+
+func openHeightKey(prefix []byte, height int64, id []byte) []byte {
+	buf := bytes.NewBuffer(prefix)
+
+	if err := binary.Write(buf, binary.BigEndian, uint64(height)); err != nil { // nolint: gosec
+		panic(err)
+	}
+
+	buf.Write(id)
+
+	return buf.Bytes()
+}
+
 func OrdersForGroupPrefix(statePrefix []byte, id dtypes.GroupID) []byte {
 	buf := bytes.NewBuffer(OrderPrefix)
 	buf.Write(statePrefix)
@@ -1,8 +1,11 @@
 package v1beta4_test
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/stretchr/testify/require"
 
 	_ "github.com/akash-network/node/testutil"
@@ -33,3 +36,40 @@ func TestKeysAndSecondaryKeysFilter(t *testing.T) {
 	// require.False(t, isSecondary)
 	require.Equal(t, keys.LeasePrefix, prefix[0:2])
 }
+
+func TestPriceIndexKey(t *testing.T) {
+	price := sdk.NewDecCoinFromDec("uakt", sdk.MustNewDecFromStr("12.5"))
+
+	key := keys.PriceIndexKey(keys.PriceIndexBidPrefixID, "cpu=100", price, 1, []byte{0x01})
+	require.True(t, bytes.HasPrefix(key, keys.PriceIndexPrefixFor(keys.PriceIndexBidPrefixID, "cpu=100", "uakt")))
+
+	bucket, parsed, err := keys.ParsePriceIndexKey(key)
+	require.NoError(t, err)
+	require.Equal(t, "cpu=100", bucket)
+	require.Equal(t, price, parsed)
+
+	// entries of the same bucket and denomination are ordered by price
+	higher := keys.PriceIndexKey(keys.PriceIndexBidPrefixID, "cpu=100", sdk.NewDecCoin("uakt", sdk.NewInt(100)), 0, []byte{0x00})
+	require.Equal(t, -1, bytes.Compare(key, higher))
+
+	// counts are keyed by the same bucket and denomination as the entries they count
+	count := keys.PriceCountKey(keys.PriceIndexBidPrefixID, "cpu=100", "uakt")
+	require.True(t, bytes.HasPrefix(count, keys.PriceCountPrefix))
+	require.Equal(t, keys.PriceIndexPrefixFor(keys.PriceIndexBidPrefixID, "cpu=100", "uakt"), keys.PriceIndexPrefixFromCountKey(count))
+
+	_, _, err = keys.ParsePriceIndexKey(key[:len(keys.PriceIndexPrefix)+4])
+	require.ErrorIs(t, err, keys.ErrInvalidPriceIndexKey)
+
+	// long buckets are bounded and kept distinct
+	long := strings.Repeat("gpu-model=a100,", 10000)
+
+	key = keys.PriceIndexKey(keys.PriceIndexBidPrefixID, long+"x", price, 0, []byte{0x01})
+	bucket, _, err = keys.ParsePriceIndexKey(key)
+	require.NoError(t, err)
+	require.Len(t, bucket, keys.MaxPriceBucketLen)
+
+	other := keys.PriceIndexKey(keys.PriceIndexBidPrefixID, long+"y", price, 0, []byte{0x01})
+	obucket, _, err := keys.ParsePriceIndexKey(other)
+	require.NoError(t, err)
+	require.NotEqual(t, bucket, obucket)
+}
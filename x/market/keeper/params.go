@@ -0,0 +1,99 @@
+package keeper
+
+import (
+	"fmt"
+
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+)
+
+const (
+	keyOrderTTL              = "OrderTTL"
+	keyBidTTL                = "BidTTL"
+	keyExpirationBlockBudget = "ExpirationBlockBudget"
+)
+
+const (
+	// DefaultOrderTTL is the default number of blocks an order may stay open before it expires (~7 days)
+	DefaultOrderTTL int64 = 100800
+	// DefaultBidTTL is the default number of blocks a bid may stay open before it expires (~24h)
+	DefaultBidTTL int64 = 14400
+	// DefaultExpirationBlockBudget is the default maximum number of orders and bids expired per block
+	DefaultExpirationBlockBudget uint32 = 100
+)
+
+var _ paramtypes.ParamSet = (*ExpirationParams)(nil)
+
+// ExpirationParams defines the governance-tunable expiration parameters of the market module.
+// They are stored alongside types.Params in the market param space and carried in market genesis
+// next to the API genesis fields. Values missing from the param store fall back to DefaultExpirationParams.
+type ExpirationParams struct {
+	// OrderTTL is the number of blocks an order may stay open. Zero disables order expiration.
+	OrderTTL int64 `json:"order_ttl" yaml:"order_ttl"`
+	// BidTTL is the number of blocks a bid may stay open. Zero disables bid expiration.
+	BidTTL int64 `json:"bid_ttl" yaml:"bid_ttl"`
+	// ExpirationBlockBudget is the maximum number of orders and bids closed by the EndBlocker
+	// in a single block. Open bids of an expired order are closed along with it and count against
+	// the budget. Expired orders and bids beyond the budget are closed in following blocks, oldest first.
+	// Zero disables expiration.
+	ExpirationBlockBudget uint32 `json:"expiration_block_budget" yaml:"expiration_block_budget"`
+}
+
+// ParamKeyTable returns market key table extended with expiration params
+func ParamKeyTable() paramtypes.KeyTable {
+	return types.ParamKeyTable().RegisterParamSet(&ExpirationParams{})
+}
+
+func (p *ExpirationParams) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{
+		paramtypes.NewParamSetPair([]byte(keyOrderTTL), &p.OrderTTL, validateTTL),
+		paramtypes.NewParamSetPair([]byte(keyBidTTL), &p.BidTTL, validateTTL),
+		paramtypes.NewParamSetPair([]byte(keyExpirationBlockBudget), &p.ExpirationBlockBudget, validateExpirationBlockBudget),
+	}
+}
+
+func DefaultExpirationParams() ExpirationParams {
+	return ExpirationParams{
+		OrderTTL:              DefaultOrderTTL,
+		BidTTL:                DefaultBidTTL,
+		ExpirationBlockBudget: DefaultExpirationBlockBudget,
+	}
+}
+
+func (p ExpirationParams) Validate() error {
+	if err := validateTTL(p.OrderTTL); err != nil {
+		return err
+	}
+
+	if err := validateTTL(p.BidTTL); err != nil {
+		return err
+	}
+
+	if err := validateExpirationBlockBudget(p.ExpirationBlockBudget); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func validateTTL(i interface{}) error {
+	val, ok := i.(int64)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	if val < 0 {
+		return fmt.Errorf("ttl must not be negative: %d", val)
+	}
+
+	return nil
+}
+
+func validateExpirationBlockBudget(i interface{}) error {
+	if _, ok := i.(uint32); !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+
+	return nil
+}
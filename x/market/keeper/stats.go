@@ -0,0 +1,355 @@
+package keeper
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	keys "github.com/akash-network/node/x/market/keeper/keys/v1beta4"
+)
+
+// PriceStats are statistics of per block prices of a resource bucket in given denomination
+type PriceStats struct {
+	Bucket string  `json:"bucket"`
+	Denom  string  `json:"denom"`
+	Count  uint64  `json:"count"`
+	Min    sdk.Dec `json:"min"`
+	Median sdk.Dec `json:"median"`
+	Max    sdk.Dec `json:"max"`
+}
+
+// MarketPriceStats are price statistics of active leases and open bids
+type MarketPriceStats struct {
+	Leases []PriceStats `json:"leases"`
+	Bids   []PriceStats `json:"bids"`
+}
+
+// ResourceBucket returns resource shape of single resource unit instance used to aggregate prices.
+// Bucket is made of CPU millis, memory bytes, storage classes, GPU units and GPU models.
+func ResourceBucket(unit dtypes.ResourceUnit) string {
+	var cpu, memory, gpu uint64
+
+	if unit.CPU != nil {
+		cpu = unit.CPU.Units.Value()
+	}
+
+	if unit.Memory != nil {
+		memory = unit.Memory.Quantity.Value()
+	}
+
+	storage := make([]string, 0, len(unit.Storage))
+	for _, vol := range unit.Storage {
+		class := "ephemeral"
+		for _, attr := range vol.Attributes {
+			if attr.Key == "class" {
+				class = attr.Value
+			}
+		}
+		storage = append(storage, class)
+	}
+	sort.Strings(storage)
+
+	bucket := fmt.Sprintf("cpu=%d;memory=%d;storage=%s", cpu, memory, strings.Join(storage, ","))
+
+	if unit.GPU != nil {
+		gpu = unit.GPU.Units.Value()
+	}
+
+	if gpu == 0 {
+		return bucket
+	}
+
+	models := make([]string, 0, len(unit.GPU.Attributes))
+	for _, attr := range unit.GPU.Attributes {
+		models = append(models, attr.Key)
+	}
+	sort.Strings(models)
+
+	return fmt.Sprintf("%s;gpu=%d;gpu-model=%s", bucket, gpu, strings.Join(models, ","))
+}
+
+// UnitPrices splits price of a group into per block prices of single instance of each resource unit.
+// Price is split proportionally to unit prices requested by the tenant, evenly if those are zero.
+func UnitPrices(spec dtypes.GroupSpec, price sdk.DecCoin) []sdk.DecCoin {
+	res := make([]sdk.DecCoin, len(spec.Resources))
+
+	total := sdk.ZeroDec()
+	instances := sdk.ZeroDec()
+
+	for _, unit := range spec.Resources {
+		count := sdk.NewDec(int64(unit.Count))
+		total = total.Add(unit.Price.Amount.Mul(count))
+		instances = instances.Add(count)
+	}
+
+	for idx, unit := range spec.Resources {
+		amount := sdk.ZeroDec()
+
+		switch {
+		case unit.Count == 0:
+		case total.IsPositive():
+			amount = price.Amount.Mul(unit.Price.Amount).Quo(total)
+		case instances.IsPositive():
+			amount = price.Amount.Quo(instances)
+		}
+
+		res[idx] = sdk.NewDecCoinFromDec(price.Denom, amount)
+	}
+
+	return res
+}
+
+// PriceStats returns price statistics of active leases and open bids aggregated
+// by resource bucket and denomination
+func (k Keeper) PriceStats(ctx sdk.Context) (MarketPriceStats, error) {
+	leases, err := k.priceStats(ctx, keys.PriceIndexLeasePrefixID)
+	if err != nil {
+		return MarketPriceStats{}, err
+	}
+
+	bids, err := k.priceStats(ctx, keys.PriceIndexBidPrefixID)
+	if err != nil {
+		return MarketPriceStats{}, err
+	}
+
+	return MarketPriceStats{
+		Leases: leases,
+		Bids:   bids,
+	}, nil
+}
+
+// RebuildPriceIndex drops price index and indexes all active leases and open bids.
+// Used when market state is imported rather than created by keeper.
+func (k Keeper) RebuildPriceIndex(ctx sdk.Context) {
+	store := ctx.KVStore(k.skey)
+
+	var stale [][]byte
+
+	for _, prefix := range [][]byte{keys.PriceIndexPrefix, keys.PriceCountPrefix} {
+		iter := sdk.KVStorePrefixIterator(store, prefix)
+		for ; iter.Valid(); iter.Next() {
+			stale = append(stale, iter.Key())
+		}
+		_ = iter.Close()
+	}
+
+	for _, key := range stale {
+		store.Delete(key)
+	}
+
+	var leases []types.Lease
+	k.WithLeases(ctx, func(lease types.Lease) bool {
+		if lease.State == types.LeaseActive {
+			leases = append(leases, lease)
+		}
+		return false
+	})
+
+	var bids []types.Bid
+	k.WithBids(ctx, func(bid types.Bid) bool {
+		if bid.State == types.BidOpen {
+			bids = append(bids, bid)
+		}
+		return false
+	})
+
+	for _, lease := range leases {
+		k.indexLeasePrice(ctx, lease)
+	}
+
+	for _, bid := range bids {
+		k.indexBidPrice(ctx, bid)
+	}
+}
+
+// priceStats walks price counts of the source, one per bucket and denomination.
+// Min and max are the first and the last index entries of the bucket and denomination,
+// median is reached by skipping half of them. Prices are never loaded all at once.
+func (k Keeper) priceStats(ctx sdk.Context, source byte) ([]PriceStats, error) {
+	store := ctx.KVStore(k.skey)
+	iter := sdk.KVStorePrefixIterator(store, keys.PriceCountKey(source, "", ""))
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	res := make([]PriceStats, 0)
+
+	for ; iter.Valid(); iter.Next() {
+		count := binary.BigEndian.Uint64(iter.Value())
+		if count == 0 {
+			continue
+		}
+
+		stats, err := priceStatsOf(store, keys.PriceIndexPrefixFromCountKey(iter.Key()), count)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, stats)
+	}
+
+	return res, nil
+}
+
+// priceStatsOf returns statistics of count price index entries under prefix
+func priceStatsOf(store sdk.KVStore, prefix []byte, count uint64) (PriceStats, error) {
+	first := func(iter sdk.Iterator) (string, sdk.DecCoin, error) {
+		defer func() {
+			_ = iter.Close()
+		}()
+
+		if !iter.Valid() {
+			return "", sdk.DecCoin{}, keys.ErrInvalidPriceIndexKey
+		}
+
+		return keys.ParsePriceIndexKey(iter.Key())
+	}
+
+	bucket, lowest, err := first(sdk.KVStorePrefixIterator(store, prefix))
+	if err != nil {
+		return PriceStats{}, err
+	}
+
+	_, highest, err := first(sdk.KVStoreReversePrefixIterator(store, prefix))
+	if err != nil {
+		return PriceStats{}, err
+	}
+
+	median, err := priceMedian(store, prefix, count)
+	if err != nil {
+		return PriceStats{}, err
+	}
+
+	return PriceStats{
+		Bucket: bucket,
+		Denom:  lowest.Denom,
+		Count:  count,
+		Min:    lowest.Amount,
+		Median: median,
+		Max:    highest.Amount,
+	}, nil
+}
+
+// priceMedian skips to the middle of count price index entries under prefix.
+// Cost is linear in count, entries are skipped without being decoded
+func priceMedian(store sdk.KVStore, prefix []byte, count uint64) (sdk.Dec, error) {
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	mid := (count - 1) / 2
+	for i := uint64(0); i < mid && iter.Valid(); i++ {
+		iter.Next()
+	}
+
+	// even number of prices has median in between two middle ones
+	want := 1
+	if count%2 == 0 {
+		want = 2
+	}
+
+	prices := make([]sdk.Dec, 0, want)
+
+	for ; iter.Valid() && len(prices) < want; iter.Next() {
+		_, price, err := keys.ParsePriceIndexKey(iter.Key())
+		if err != nil {
+			return sdk.Dec{}, err
+		}
+
+		prices = append(prices, price.Amount)
+	}
+
+	switch len(prices) {
+	case 1:
+		return prices[0], nil
+	case 2:
+		return prices[0].Add(prices[1]).QuoInt64(2), nil
+	default:
+		return sdk.Dec{}, keys.ErrInvalidPriceIndexKey
+	}
+}
+
+func (k Keeper) indexLeasePrice(ctx sdk.Context, lease types.Lease) {
+	k.updatePriceIndex(ctx, keys.PriceIndexLeasePrefixID, lease.LeaseID.OrderID(), lease.Price,
+		keys.MustLeaseKey(nil, lease.LeaseID)[len(keys.LeasePrefix):], false)
+}
+
+func (k Keeper) unindexLeasePrice(ctx sdk.Context, lease types.Lease) {
+	k.updatePriceIndex(ctx, keys.PriceIndexLeasePrefixID, lease.LeaseID.OrderID(), lease.Price,
+		keys.MustLeaseKey(nil, lease.LeaseID)[len(keys.LeasePrefix):], true)
+}
+
+func (k Keeper) indexBidPrice(ctx sdk.Context, bid types.Bid) {
+	k.updatePriceIndex(ctx, keys.PriceIndexBidPrefixID, bid.BidID.OrderID(), bid.Price,
+		keys.MustBidKey(nil, bid.BidID)[len(keys.BidPrefix):], false)
+}
+
+func (k Keeper) unindexBidPrice(ctx sdk.Context, bid types.Bid) {
+	k.updatePriceIndex(ctx, keys.PriceIndexBidPrefixID, bid.BidID.OrderID(), bid.Price,
+		keys.MustBidKey(nil, bid.BidID)[len(keys.BidPrefix):], true)
+}
+
+// updatePriceIndex sets or deletes price index entries of every resource unit of the order
+func (k Keeper) updatePriceIndex(ctx sdk.Context, source byte, oid types.OrderID, price sdk.DecCoin, id []byte, remove bool) {
+	order, found := k.GetOrder(ctx, oid)
+	if !found {
+		return
+	}
+
+	store := ctx.KVStore(k.skey)
+	prices := UnitPrices(order.Spec, price)
+
+	for idx, unit := range order.Spec.Resources {
+		if unit.Count == 0 {
+			continue
+		}
+
+		bucket := ResourceBucket(unit)
+		key := keys.PriceIndexKey(source, bucket, prices[idx], uint32(idx), id) // nolint: gosec
+
+		// count only actual changes, entry may be indexed or unindexed more than once
+		if store.Has(key) != remove {
+			continue
+		}
+
+		if remove {
+			store.Delete(key)
+		} else {
+			store.Set(key, []byte{})
+		}
+
+		updatePriceCount(store, keys.PriceCountKey(source, bucket, prices[idx].Denom), remove)
+	}
+}
+
+// updatePriceCount increments or decrements number of price index entries of bucket and denomination
+func updatePriceCount(store sdk.KVStore, key []byte, decrement bool) {
+	var count uint64
+	if buf := store.Get(key); buf != nil {
+		count = binary.BigEndian.Uint64(buf)
+	}
+
+	if decrement {
+		count--
+	} else {
+		count++
+	}
+
+	if count == 0 {
+		store.Delete(key)
+		return
+	}
+
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, count)
+	store.Set(key, buf)
+}
@@ -0,0 +1,180 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	"github.com/akash-network/node/testutil"
+	"github.com/akash-network/node/x/market/keeper"
+)
+
+func Test_UnitPrices(t *testing.T) {
+	spec := dtypes.GroupSpec{
+		Resources: dtypes.ResourceUnits{
+			{Count: 2, Price: sdk.NewDecCoin("uakt", sdk.NewInt(10))},
+			{Count: 1, Price: sdk.NewDecCoin("uakt", sdk.NewInt(30))},
+		},
+	}
+
+	prices := keeper.UnitPrices(spec, sdk.NewDecCoin("uakt", sdk.NewInt(100)))
+	require.Equal(t, []sdk.DecCoin{
+		sdk.NewDecCoin("uakt", sdk.NewInt(20)),
+		sdk.NewDecCoin("uakt", sdk.NewInt(60)),
+	}, prices)
+
+	// tenant did not price units, group price is split evenly among instances
+	spec.Resources[0].Price.Amount = sdk.ZeroDec()
+	spec.Resources[1].Price.Amount = sdk.ZeroDec()
+
+	prices = keeper.UnitPrices(spec, sdk.NewDecCoin("uakt", sdk.NewInt(90)))
+	require.Equal(t, []sdk.DecCoin{
+		sdk.NewDecCoin("uakt", sdk.NewInt(30)),
+		sdk.NewDecCoin("uakt", sdk.NewInt(30)),
+	}, prices)
+}
+
+func Test_PriceStats(t *testing.T) {
+	ctx, mkeeper, _ := setupKeeper(t)
+
+	group := testutil.DeploymentGroup(t, testutil.DeploymentID(t), 0)
+	group.GroupSpec.Resources = group.GroupSpec.Resources[:1]
+	group.GroupSpec.Resources[0].Count = 1
+
+	bucket := keeper.ResourceBucket(group.GroupSpec.Resources[0])
+
+	order, err := mkeeper.CreateOrder(ctx, group.ID(), group.GroupSpec)
+	require.NoError(t, err)
+
+	roffer := types.ResourceOfferFromRU(group.GroupSpec.Resources)
+
+	var bids []types.Bid
+	for _, price := range []int64{40, 10, 20, 30} {
+		bid, err := mkeeper.CreateBid(ctx, order.ID(), testutil.AccAddress(t), sdk.NewDecCoin("uakt", sdk.NewInt(price)), roffer)
+		require.NoError(t, err)
+		bids = append(bids, bid)
+	}
+
+	stats, err := mkeeper.PriceStats(ctx)
+	require.NoError(t, err)
+	require.Empty(t, stats.Leases)
+	require.Equal(t, []keeper.PriceStats{{
+		Bucket: bucket,
+		Denom:  "uakt",
+		Count:  4,
+		Min:    sdk.NewDec(10),
+		Median: sdk.NewDec(25),
+		Max:    sdk.NewDec(40),
+	}}, stats.Bids)
+
+	// cheapest bid wins, the others are lost
+	mkeeper.CreateLease(ctx, bids[1])
+	mkeeper.OnBidMatched(ctx, bids[1])
+	mkeeper.OnOrderMatched(ctx, order)
+	mkeeper.OnBidLost(ctx, bids[0])
+
+	stats, err = mkeeper.PriceStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []keeper.PriceStats{{
+		Bucket: bucket,
+		Denom:  "uakt",
+		Count:  1,
+		Min:    sdk.NewDec(10),
+		Median: sdk.NewDec(10),
+		Max:    sdk.NewDec(10),
+	}}, stats.Leases)
+	require.Equal(t, []keeper.PriceStats{{
+		Bucket: bucket,
+		Denom:  "uakt",
+		Count:  2,
+		Min:    sdk.NewDec(20),
+		Median: sdk.NewDec(25),
+		Max:    sdk.NewDec(30),
+	}}, stats.Bids)
+
+	// rebuilt index matches incrementally maintained one
+	mkeeper.RebuildPriceIndex(ctx)
+
+	rebuilt, err := mkeeper.PriceStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, stats, rebuilt)
+
+	lease, found := mkeeper.GetLease(ctx, bids[1].ID().LeaseID())
+	require.True(t, found)
+
+	mkeeper.OnLeaseClosed(ctx, lease, types.LeaseClosed)
+
+	stats, err = mkeeper.PriceStats(ctx)
+	require.NoError(t, err)
+	require.Empty(t, stats.Leases)
+}
+
+func Test_PriceStatsCounts(t *testing.T) {
+	ctx, mkeeper, _ := setupKeeper(t)
+
+	group := testutil.DeploymentGroup(t, testutil.DeploymentID(t), 0)
+	group.GroupSpec.Resources = group.GroupSpec.Resources[:1]
+	group.GroupSpec.Resources[0].Count = 1
+
+	bucket := keeper.ResourceBucket(group.GroupSpec.Resources[0])
+
+	order, err := mkeeper.CreateOrder(ctx, group.ID(), group.GroupSpec)
+	require.NoError(t, err)
+
+	roffer := types.ResourceOfferFromRU(group.GroupSpec.Resources)
+
+	var bids []types.Bid
+	for _, price := range []sdk.DecCoin{
+		sdk.NewDecCoin("uakt", sdk.NewInt(50)),
+		sdk.NewDecCoin("uakt", sdk.NewInt(10)),
+		sdk.NewDecCoin("uakt", sdk.NewInt(30)),
+		sdk.NewDecCoin("uusdc", sdk.NewInt(7)),
+	} {
+		bid, err := mkeeper.CreateBid(ctx, order.ID(), testutil.AccAddress(t), price, roffer)
+		require.NoError(t, err)
+		bids = append(bids, bid)
+	}
+
+	stats, err := mkeeper.PriceStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []keeper.PriceStats{{
+		Bucket: bucket,
+		Denom:  "uakt",
+		Count:  3,
+		Min:    sdk.NewDec(10),
+		Median: sdk.NewDec(30),
+		Max:    sdk.NewDec(50),
+	}, {
+		Bucket: bucket,
+		Denom:  "uusdc",
+		Count:  1,
+		Min:    sdk.NewDec(7),
+		Median: sdk.NewDec(7),
+		Max:    sdk.NewDec(7),
+	}}, stats.Bids)
+
+	// unindexing the same bid twice is counted once
+	mkeeper.OnBidLost(ctx, bids[3])
+	mkeeper.OnBidLost(ctx, bids[3])
+
+	stats, err = mkeeper.PriceStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []keeper.PriceStats{{
+		Bucket: bucket,
+		Denom:  "uakt",
+		Count:  3,
+		Min:    sdk.NewDec(10),
+		Median: sdk.NewDec(30),
+		Max:    sdk.NewDec(50),
+	}}, stats.Bids)
+
+	mkeeper.RebuildPriceIndex(ctx)
+
+	rebuilt, err := mkeeper.PriceStats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, stats, rebuilt)
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/akash-network/node/x/market/client/rest"
 	"github.com/akash-network/node/x/market/handler"
 	"github.com/akash-network/node/x/market/keeper"
+	"github.com/akash-network/node/x/market/query"
 	"github.com/akash-network/node/x/market/simulation"
 )
 
@@ -67,17 +68,21 @@ func (b AppModuleBasic) RegisterInterfaces(registry cdctypes.InterfaceRegistry)
 // DefaultGenesis returns default genesis state as raw bytes for the market
 // module.
 func (AppModuleBasic) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
-	return cdc.MustMarshalJSON(DefaultGenesisState())
+	bz, err := MarshalGenesis(cdc, DefaultGenesisState())
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
 }
 
 // ValidateGenesis validation check of the Genesis
 func (AppModuleBasic) ValidateGenesis(cdc codec.JSONCodec, _ client.TxEncodingConfig, bz json.RawMessage) error {
-	var data types.GenesisState
-	err := cdc.UnmarshalJSON(bz, &data)
+	data, err := UnmarshalGenesis(cdc, bz)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal %s genesis state: %w", types.ModuleName, err)
 	}
-	return ValidateGenesis(&data)
+	return ValidateGenesis(data)
 }
 
 // RegisterRESTRoutes registers rest routes for this module
@@ -143,7 +148,9 @@ func (AppModule) Name() string {
 }
 
 // RegisterInvariants registers module invariants
-func (am AppModule) RegisterInvariants(_ sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	keeper.RegisterInvariants(ir, am.keepers.Market, am.keepers.Deployment, am.keepers.Escrow)
+}
 
 // Route returns the message routing key for the market module.
 func (am AppModule) Route() sdk.Route {
@@ -152,12 +159,12 @@ func (am AppModule) Route() sdk.Route {
 
 // QuerierRoute returns the market module's querier route name.
 func (am AppModule) QuerierRoute() string {
-	return ""
+	return types.ModuleName
 }
 
 // LegacyQuerierHandler returns the sdk.Querier for market module
-func (am AppModule) LegacyQuerierHandler(_ *codec.LegacyAmino) sdk.Querier {
-	return nil
+func (am AppModule) LegacyQuerierHandler(legacyQuerierCdc *codec.LegacyAmino) sdk.Querier {
+	return query.NewQuerier(am.keepers.Market, legacyQuerierCdc)
 }
 
 // RegisterServices registers the module's services
@@ -170,30 +177,38 @@ func (am AppModule) RegisterServices(cfg module.Configurator) {
 // BeginBlock performs no-op
 func (am AppModule) BeginBlock(_ sdk.Context, _ abci.RequestBeginBlock) {}
 
-// EndBlock returns the end blocker for the market module. It returns no validator
-// updates.
-func (am AppModule) EndBlock(_ sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+// EndBlock returns the end blocker for the market module. It closes expired
+// orders and bids. It returns no validator updates.
+func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.ValidatorUpdate {
+	handler.EndBlocker(ctx, am.keepers)
 	return []abci.ValidatorUpdate{}
 }
 
 // InitGenesis performs genesis initialization for the market module. It returns
 // no validator updates.
 func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.RawMessage) []abci.ValidatorUpdate {
-	var genesisState types.GenesisState
-	cdc.MustUnmarshalJSON(data, &genesisState)
-	return InitGenesis(ctx, am.keepers.Market, &genesisState)
+	genesisState, err := UnmarshalGenesis(cdc, data)
+	if err != nil {
+		panic(err)
+	}
+
+	return InitGenesis(ctx, am.keepers.Market, genesisState)
 }
 
 // ExportGenesis returns the exported genesis state as raw bytes for the market
 // module.
 func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.RawMessage {
-	gs := ExportGenesis(ctx, am.keepers.Market)
-	return cdc.MustMarshalJSON(gs)
+	bz, err := MarshalGenesis(cdc, ExportGenesis(ctx, am.keepers.Market))
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
 }
 
 // ConsensusVersion implements module.AppModule#ConsensusVersion
 func (am AppModule) ConsensusVersion() uint64 {
-	return 6
+	return 7
 }
 
 // AppModuleSimulation implements an application simulation module for the market module.
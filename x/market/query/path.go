@@ -19,6 +19,11 @@ const (
 	bidPath    = "bid"
 	leasesPath = "leases"
 	leasePath  = "lease"
+
+	filterBidsPath   = "filter-bids"
+	filterLeasesPath = "filter-leases"
+
+	priceStatsPath = "price-stats"
 )
 
 var (
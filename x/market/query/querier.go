@@ -0,0 +1,90 @@
+package query
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	"github.com/akash-network/node/x/market/keeper"
+)
+
+// NewQuerier creates and returns a new market querier instance
+func NewQuerier(k keeper.IKeeper, cdc *codec.LegacyAmino) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		if len(path) == 0 {
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "empty %s query path", types.ModuleName)
+		}
+
+		switch path[0] {
+		case filterBidsPath:
+			return queryFilterBids(ctx, req.Data, k, cdc)
+		case filterLeasesPath:
+			return queryFilterLeases(ctx, req.Data, k, cdc)
+		case priceStatsPath:
+			return queryPriceStats(ctx, k, cdc)
+		}
+
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown %s query endpoint: %s", types.ModuleName, path[0])
+	}
+}
+
+func queryFilterBids(ctx sdk.Context, data []byte, k keeper.IKeeper, cdc *codec.LegacyAmino) ([]byte, error) {
+	var req FilterBidsRequest
+
+	if len(data) > 0 {
+		if err := cdc.UnmarshalJSON(data, &req); err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+		}
+	}
+
+	bids, pageRes, err := k.FilterBids(ctx, req.Filters, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJSON(cdc, FilterBidsResponse{
+		Bids:       bids,
+		Pagination: pageRes,
+	})
+}
+
+func queryFilterLeases(ctx sdk.Context, data []byte, k keeper.IKeeper, cdc *codec.LegacyAmino) ([]byte, error) {
+	var req FilterLeasesRequest
+
+	if len(data) > 0 {
+		if err := cdc.UnmarshalJSON(data, &req); err != nil {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+		}
+	}
+
+	leases, pageRes, err := k.FilterLeases(ctx, req.Filters, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalJSON(cdc, FilterLeasesResponse{
+		Leases:     leases,
+		Pagination: pageRes,
+	})
+}
+
+func queryPriceStats(ctx sdk.Context, k keeper.IKeeper, cdc *codec.LegacyAmino) ([]byte, error) {
+	stats, err := k.PriceStats(ctx)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrLogic, err.Error())
+	}
+
+	return marshalJSON(cdc, stats)
+}
+
+func marshalJSON(cdc *codec.LegacyAmino, obj interface{}) ([]byte, error) {
+	res, err := codec.MarshalJSONIndent(cdc, obj)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return res, nil
+}
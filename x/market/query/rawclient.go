@@ -16,6 +16,9 @@ type RawClient interface {
 	Bid(id types.BidID) ([]byte, error)
 	Leases(filters LeaseFilters) ([]byte, error)
 	Lease(id types.LeaseID) ([]byte, error)
+	FilterBids(req FilterBidsRequest) ([]byte, error)
+	FilterLeases(req FilterLeasesRequest) ([]byte, error)
+	PriceStats() ([]byte, error)
 }
 
 // NewRawClient creates a raw client instance with provided context and key
@@ -75,3 +78,37 @@ func (c *rawclient) Lease(id types.LeaseID) ([]byte, error) {
 	}
 	return buf, nil
 }
+
+func (c *rawclient) FilterBids(req FilterBidsRequest) ([]byte, error) {
+	data, err := c.ctx.LegacyAmino.MarshalJSON(req)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	buf, _, err := c.ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", c.key, filterBidsPath), data)
+	if err != nil {
+		return []byte{}, err
+	}
+	return buf, nil
+}
+
+func (c *rawclient) FilterLeases(req FilterLeasesRequest) ([]byte, error) {
+	data, err := c.ctx.LegacyAmino.MarshalJSON(req)
+	if err != nil {
+		return []byte{}, err
+	}
+
+	buf, _, err := c.ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", c.key, filterLeasesPath), data)
+	if err != nil {
+		return []byte{}, err
+	}
+	return buf, nil
+}
+
+func (c *rawclient) PriceStats() ([]byte, error) {
+	buf, _, err := c.ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", c.key, priceStatsPath), nil)
+	if err != nil {
+		return []byte{}, err
+	}
+	return buf, nil
+}
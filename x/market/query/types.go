@@ -2,8 +2,11 @@ package query
 
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkquery "github.com/cosmos/cosmos-sdk/types/query"
 
 	types "github.com/akash-network/akash-api/go/node/market/v1beta4"
+
+	"github.com/akash-network/node/x/market/keeper"
 )
 
 type (
@@ -27,6 +30,30 @@ const (
 	todo = "TODO see deployment/query/types.go"
 )
 
+// FilterBidsRequest is the request of bid filter query
+type FilterBidsRequest struct {
+	Filters    keeper.BidQueryFilters `json:"filters"`
+	Pagination *sdkquery.PageRequest  `json:"pagination"`
+}
+
+// FilterBidsResponse is the response of bid filter query
+type FilterBidsResponse struct {
+	Bids       []types.Bid            `json:"bids"`
+	Pagination *sdkquery.PageResponse `json:"pagination"`
+}
+
+// FilterLeasesRequest is the request of lease filter query
+type FilterLeasesRequest struct {
+	Filters    keeper.LeaseQueryFilters `json:"filters"`
+	Pagination *sdkquery.PageRequest    `json:"pagination"`
+}
+
+// FilterLeasesResponse is the response of lease filter query
+type FilterLeasesResponse struct {
+	Leases     []types.Lease          `json:"leases"`
+	Pagination *sdkquery.PageResponse `json:"pagination"`
+}
+
 // OrderFilters defines flags for order list filter
 type OrderFilters struct {
 	Owner sdk.AccAddress
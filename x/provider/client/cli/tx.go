@@ -12,9 +12,14 @@ import (
 	types "github.com/akash-network/akash-api/go/node/provider/v1beta3"
 
 	aclient "github.com/akash-network/node/client"
+	"github.com/akash-network/node/util/attributes"
 	"github.com/akash-network/node/x/provider/config"
 )
 
+const (
+	flagAttributesMode = "attributes-mode"
+)
+
 // GetTxCmd returns the transaction commands for provider module
 func GetTxCmd(key string) *cobra.Command {
 	cmd := &cobra.Command{
@@ -60,6 +65,10 @@ func cmdCreate(key string) *cobra.Command {
 				return err
 			}
 
+			if err := validateAttributes(cmd, cfg); err != nil {
+				return err
+			}
+
 			msg := &types.MsgCreateProvider{
 				Owner:      cctx.GetFromAddress().String(),
 				HostURI:    cfg.Host,
@@ -80,6 +89,7 @@ func cmdCreate(key string) *cobra.Command {
 		},
 	}
 
+	addAttributesModeFlag(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
@@ -113,6 +123,10 @@ func cmdUpdate(key string) *cobra.Command {
 				return err
 			}
 
+			if err := validateAttributes(cmd, cfg); err != nil {
+				return err
+			}
+
 			msg := &types.MsgUpdateProvider{
 				Owner:      cctx.GetFromAddress().String(),
 				HostURI:    cfg.Host,
@@ -133,7 +147,38 @@ func cmdUpdate(key string) *cobra.Command {
 		},
 	}
 
+	addAttributesModeFlag(cmd)
 	flags.AddTxFlagsToCmd(cmd)
 
 	return cmd
 }
+
+func addAttributesModeFlag(cmd *cobra.Command) {
+	cmd.Flags().String(flagAttributesMode, attributes.ModeStrict.String(),
+		"provider attributes check against the well-known attribute registry (strict|warn)")
+}
+
+// validateAttributes checks config attributes against the well-known attribute registry
+// in the mode set by the --attributes-mode flag. Warnings are printed to stderr
+func validateAttributes(cmd *cobra.Command, cfg config.Config) error {
+	val, err := cmd.Flags().GetString(flagAttributesMode)
+	if err != nil {
+		return err
+	}
+
+	mode, err := attributes.ParseMode(val)
+	if err != nil {
+		return err
+	}
+
+	warnings, err := cfg.ValidateAttributes(mode)
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		cmd.PrintErrln("warning:", warning)
+	}
+
+	return nil
+}
@@ -10,6 +10,8 @@ import (
 	ptypes "github.com/akash-network/akash-api/go/node/provider/v1beta3"
 
 	types "github.com/akash-network/akash-api/go/node/types/v1beta3"
+
+	"github.com/akash-network/node/util/attributes"
 )
 
 var (
@@ -28,6 +30,12 @@ func (c Config) GetAttributes() types.Attributes {
 	return c.Attributes
 }
 
+// ValidateAttributes checks config attributes against the well-known attribute registry.
+// In warn mode registry violations are returned as warnings, in strict mode as error.
+func (c Config) ValidateAttributes(mode attributes.Mode) ([]error, error) {
+	return attributes.Validate(c.Attributes, mode)
+}
+
 // ReadConfigPath reads and parses file
 func ReadConfigPath(path string) (Config, error) {
 	buf, err := os.ReadFile(path)
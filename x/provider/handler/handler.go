@@ -11,8 +11,8 @@ import (
 )
 
 // NewHandler returns a handler for "provider" type messages.
-func NewHandler(keeper keeper.IKeeper, mkeeper mkeeper.IKeeper) sdk.Handler {
-	ms := NewMsgServerImpl(keeper, mkeeper)
+func NewHandler(keeper keeper.IKeeper, mkeeper mkeeper.IKeeper, ekeeper EscrowKeeper, dkeeper DeploymentKeeper) sdk.Handler {
+	ms := NewMsgServerImpl(keeper, mkeeper, ekeeper, dkeeper)
 
 	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
 		switch msg := msg.(type) {
@@ -7,14 +7,23 @@ import (
 	sdktestdata "github.com/cosmos/cosmos-sdk/testutil/testdata"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/rand"
 
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	etypes "github.com/akash-network/akash-api/go/node/escrow/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
 	types "github.com/akash-network/akash-api/go/node/provider/v1beta3"
 
 	akashtypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
+	"github.com/akash-network/akash-api/go/sdkutil"
 
 	"github.com/akash-network/node/testutil"
+	emocks "github.com/akash-network/node/testutil/cosmos/mocks"
 	"github.com/akash-network/node/testutil/state"
+	dkeeper "github.com/akash-network/node/x/deployment/keeper"
+	ekeeper "github.com/akash-network/node/x/escrow/keeper"
 	mkeeper "github.com/akash-network/node/x/market/keeper"
 	"github.com/akash-network/node/x/provider/handler"
 	"github.com/akash-network/node/x/provider/keeper"
@@ -24,11 +33,16 @@ const (
 	emailValid = "test@example.com"
 )
 
+var defaultDeposit = sdk.NewInt64Coin(testutil.CoinDenom, 100000000)
+
 type testSuite struct {
 	t       testing.TB
 	ctx     sdk.Context
 	keeper  keeper.IKeeper
 	mkeeper mkeeper.IKeeper
+	dkeeper dkeeper.IKeeper
+	ekeeper ekeeper.Keeper
+	bkeeper *emocks.BankKeeper
 	handler sdk.Handler
 }
 
@@ -39,9 +53,12 @@ func setupTestSuite(t *testing.T) *testSuite {
 		ctx:     ssuite.Context(),
 		keeper:  ssuite.ProviderKeeper(),
 		mkeeper: ssuite.MarketKeeper(),
+		dkeeper: ssuite.DeploymentKeeper(),
+		ekeeper: ssuite.EscrowKeeper(),
+		bkeeper: ssuite.BankKeeper(),
 	}
 
-	suite.handler = handler.NewHandler(suite.keeper, suite.mkeeper)
+	suite.handler = handler.NewHandler(suite.keeper, suite.mkeeper, suite.ekeeper, suite.dkeeper)
 
 	return suite
 }
@@ -114,6 +131,29 @@ func TestProviderCreateWithInfo(t *testing.T) {
 	require.True(t, errors.Is(err, types.ErrProviderExists))
 }
 
+func TestProviderCreateWithUnregisteredAttributes(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	// registry violations are not enforced on chain
+	msg := &types.MsgCreateProvider{
+		Owner:   testutil.AccAddress(t).String(),
+		HostURI: testutil.ProviderHostname(t),
+		Attributes: akashtypes.Attributes{
+			{Key: "regoin", Value: "us-west"},
+			{Key: "tier", Value: "gold"},
+		},
+	}
+
+	res, err := suite.handler(suite.ctx, msg)
+	require.NotNil(t, res)
+	require.NoError(t, err)
+
+	owner, _ := sdk.AccAddressFromBech32(msg.Owner)
+	provider, found := suite.keeper.Get(suite.ctx, owner)
+	require.True(t, found)
+	require.Equal(t, msg.Attributes, provider.Attributes)
+}
+
 func TestProviderCreateWithDuplicated(t *testing.T) {
 	suite := setupTestSuite(t)
 
@@ -232,28 +272,271 @@ func TestProviderUpdateAttributes(t *testing.T) {
 func TestProviderDeleteExisting(t *testing.T) {
 	suite := setupTestSuite(t)
 
-	addr := testutil.AccAddress(t)
-
-	createMsg := &types.MsgCreateProvider{
-		Owner:   addr.String(),
-		HostURI: testutil.ProviderHostname(t),
-	}
+	owner := suite.createProvider()
 
 	deleteMsg := &types.MsgDeleteProvider{
-		Owner: addr.String(),
+		Owner: owner.String(),
 	}
 
-	err := suite.keeper.Create(suite.ctx, types.Provider(*createMsg))
+	res, err := suite.handler(suite.ctx, deleteMsg)
 	require.NoError(t, err)
+	require.NotNil(t, res)
 
-	res, err := suite.handler(suite.ctx, deleteMsg)
+	t.Run("ensure event created", func(t *testing.T) {
+		iev := testutil.ParseProviderEvent(t, res.Events[1:])
+		require.IsType(t, types.EventProviderDeleted{}, iev)
+
+		dev := iev.(types.EventProviderDeleted)
+
+		require.Equal(t, deleteMsg.Owner, dev.Owner.String())
+	})
+
+	_, found := suite.keeper.Get(suite.ctx, owner)
+	require.False(t, found)
+
+	res, err = suite.handler(suite.ctx, deleteMsg)
 	require.Nil(t, res)
-	require.EqualError(t, err, "NOTIMPLEMENTED: "+handler.ErrInternal.Error())
-	require.True(t, errors.Is(err, handler.ErrInternal))
+	require.True(t, errors.Is(err, types.ErrProviderNotFound))
+}
 
-	t.Run("ensure event created", func(t *testing.T) {
-		// TODO: this should emit a ProviderDelete
+func TestProviderDeleteWithOpenBid(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	owner := suite.createProvider()
+	bid, order := suite.createBid(owner)
+
+	res, err := suite.handler(suite.ctx, &types.MsgDeleteProvider{Owner: owner.String()})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	t.Run("ensure bid closed", func(t *testing.T) {
+		bid, found := suite.mkeeper.GetBid(suite.ctx, bid.ID())
+		require.True(t, found)
+		require.Equal(t, mtypes.BidClosed, bid.State)
+	})
+
+	t.Run("ensure order remains open", func(t *testing.T) {
+		order, found := suite.mkeeper.GetOrder(suite.ctx, order.ID())
+		require.True(t, found)
+		require.Equal(t, mtypes.OrderOpen, order.State)
+	})
+
+	_, found := suite.keeper.Get(suite.ctx, owner)
+	require.False(t, found)
+}
+
+func TestProviderDeleteWithActiveLease(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	owner := suite.createProvider()
+	lid, bid, order := suite.createLease(owner)
+
+	// let the lease accrue earnings
+	const blocks = 10
+	suite.ctx = suite.ctx.WithBlockHeight(suite.ctx.BlockHeight() + blocks)
+
+	res, err := suite.handler(suite.ctx, &types.MsgDeleteProvider{Owner: owner.String()})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	t.Run("ensure lease closed", func(t *testing.T) {
+		lease, found := suite.mkeeper.GetLease(suite.ctx, lid)
+		require.True(t, found)
+		require.Equal(t, mtypes.LeaseClosed, lease.State)
+		require.Equal(t, suite.ctx.BlockHeight(), lease.ClosedOn)
 	})
+
+	t.Run("ensure bid and order closed", func(t *testing.T) {
+		bid, found := suite.mkeeper.GetBid(suite.ctx, bid.ID())
+		require.True(t, found)
+		require.Equal(t, mtypes.BidClosed, bid.State)
+
+		order, found := suite.mkeeper.GetOrder(suite.ctx, order.ID())
+		require.True(t, found)
+		require.Equal(t, mtypes.OrderClosed, order.State)
+	})
+
+	t.Run("ensure group paused", func(t *testing.T) {
+		group, found := suite.dkeeper.GetGroup(suite.ctx, lid.GroupID())
+		require.True(t, found)
+		require.Equal(t, dtypes.GroupPaused, group.State)
+	})
+
+	t.Run("ensure escrow payment closed and withdrawn", func(t *testing.T) {
+		aid := dtypes.EscrowAccountForDeployment(lid.DeploymentID())
+		earnings := sdk.NewCoin(bid.Price.Denom, bid.Price.Amount.MulInt64(blocks).TruncateInt())
+
+		payment, err := suite.ekeeper.GetPayment(suite.ctx, aid, mtypes.EscrowPaymentForLease(lid))
+		require.NoError(t, err)
+		require.Equal(t, etypes.PaymentClosed, payment.State)
+		require.Equal(t, earnings, payment.Withdrawn)
+		require.True(t, payment.Balance.IsZero())
+
+		account, err := suite.ekeeper.GetAccount(suite.ctx, aid)
+		require.NoError(t, err)
+		require.Equal(t, etypes.AccountOpen, account.State)
+		require.Equal(t, sdk.NewDecCoinFromCoin(defaultDeposit.Sub(earnings)), account.Balance)
+		require.Equal(t, sdk.NewDecCoinFromCoin(earnings), account.Transferred)
+
+		suite.bkeeper.AssertCalled(t, "SendCoinsFromModuleToAccount", mock.Anything, etypes.ModuleName, owner, mock.Anything)
+	})
+
+	t.Run("ensure events created", func(t *testing.T) {
+		var leaseClosed, providerDeleted bool
+
+		for _, ev := range res.Events {
+			iev, err := sdkutil.ParseEvent(sdk.StringifyEvent(ev))
+			if err != nil {
+				continue
+			}
+
+			if mev, err := mtypes.ParseEvent(iev); err == nil {
+				if lev, ok := mev.(mtypes.EventLeaseClosed); ok && lev.ID.Equals(lid) {
+					leaseClosed = true
+				}
+			}
+
+			if pev, err := types.ParseEvent(iev); err == nil {
+				if _, ok := pev.(types.EventProviderDeleted); ok {
+					providerDeleted = true
+				}
+			}
+		}
+
+		require.True(t, leaseClosed)
+		require.True(t, providerDeleted)
+	})
+
+	_, found := suite.keeper.Get(suite.ctx, owner)
+	require.False(t, found)
+}
+
+func TestProviderDeleteWithClosedPayment(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	owner := suite.createProvider()
+	lid, bid, order := suite.createLease(owner)
+
+	aid := dtypes.EscrowAccountForDeployment(lid.DeploymentID())
+	pid := mtypes.EscrowPaymentForLease(lid)
+
+	// payment closed while lease is still active, escrow hooks are bypassed
+	payment, err := suite.ekeeper.GetPayment(suite.ctx, aid, pid)
+	require.NoError(t, err)
+
+	payment.State = etypes.PaymentClosed
+	suite.ekeeper.SavePayment(suite.ctx, payment)
+
+	res, err := suite.handler(suite.ctx, &types.MsgDeleteProvider{Owner: owner.String()})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	lease, found := suite.mkeeper.GetLease(suite.ctx, lid)
+	require.True(t, found)
+	require.Equal(t, mtypes.LeaseClosed, lease.State)
+
+	rbid, found := suite.mkeeper.GetBid(suite.ctx, bid.ID())
+	require.True(t, found)
+	require.Equal(t, mtypes.BidClosed, rbid.State)
+
+	rorder, found := suite.mkeeper.GetOrder(suite.ctx, order.ID())
+	require.True(t, found)
+	require.Equal(t, mtypes.OrderClosed, rorder.State)
+
+	payment, err = suite.ekeeper.GetPayment(suite.ctx, aid, pid)
+	require.NoError(t, err)
+	require.Equal(t, etypes.PaymentClosed, payment.State)
+
+	_, found = suite.keeper.Get(suite.ctx, owner)
+	require.False(t, found)
+}
+
+func TestProviderDeleteWithInsufficientFundsLease(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	owner := suite.createProvider()
+	lid, bid, order := suite.createLease(owner)
+
+	lease, found := suite.mkeeper.GetLease(suite.ctx, lid)
+	require.True(t, found)
+
+	// mirrors escrow payment being closed as overdrawn
+	suite.mkeeper.OnOrderClosed(suite.ctx, order)
+	suite.mkeeper.OnBidClosed(suite.ctx, bid)
+	suite.mkeeper.OnLeaseClosed(suite.ctx, lease, mtypes.LeaseInsufficientFunds)
+
+	res, err := suite.handler(suite.ctx, &types.MsgDeleteProvider{Owner: owner.String()})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	lease, found = suite.mkeeper.GetLease(suite.ctx, lid)
+	require.True(t, found)
+	require.Equal(t, mtypes.LeaseInsufficientFunds, lease.State)
+
+	group, found := suite.dkeeper.GetGroup(suite.ctx, lid.GroupID())
+	require.True(t, found)
+	require.Equal(t, dtypes.GroupOpen, group.State)
+
+	_, found = suite.keeper.Get(suite.ctx, owner)
+	require.False(t, found)
+}
+
+func TestProviderDeleteWithClosedLease(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	owner := suite.createProvider()
+	lid, bid, order := suite.createLease(owner)
+
+	lease, found := suite.mkeeper.GetLease(suite.ctx, lid)
+	require.True(t, found)
+
+	suite.mkeeper.OnLeaseClosed(suite.ctx, lease, mtypes.LeaseClosed)
+	suite.mkeeper.OnBidClosed(suite.ctx, bid)
+	suite.mkeeper.OnOrderClosed(suite.ctx, order)
+
+	suite.ctx = suite.ctx.WithBlockHeight(suite.ctx.BlockHeight() + 1)
+
+	res, err := suite.handler(suite.ctx, &types.MsgDeleteProvider{Owner: owner.String()})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	closed, found := suite.mkeeper.GetLease(suite.ctx, lid)
+	require.True(t, found)
+	require.Equal(t, mtypes.LeaseClosed, closed.State)
+	require.Equal(t, lease.ClosedOn, closed.ClosedOn)
+
+	_, found = suite.keeper.Get(suite.ctx, owner)
+	require.False(t, found)
+}
+
+func TestProviderDeleteKeepsOtherProviders(t *testing.T) {
+	suite := setupTestSuite(t)
+
+	owner := suite.createProvider()
+	other := suite.createProvider()
+
+	lid, _, _ := suite.createLease(owner)
+	olid, _, _ := suite.createLease(other)
+	obid, _ := suite.createBid(other)
+
+	res, err := suite.handler(suite.ctx, &types.MsgDeleteProvider{Owner: owner.String()})
+	require.NoError(t, err)
+	require.NotNil(t, res)
+
+	lease, found := suite.mkeeper.GetLease(suite.ctx, lid)
+	require.True(t, found)
+	require.Equal(t, mtypes.LeaseClosed, lease.State)
+
+	lease, found = suite.mkeeper.GetLease(suite.ctx, olid)
+	require.True(t, found)
+	require.Equal(t, mtypes.LeaseActive, lease.State)
+
+	bid, found := suite.mkeeper.GetBid(suite.ctx, obid.ID())
+	require.True(t, found)
+	require.Equal(t, mtypes.BidOpen, bid.State)
+
+	_, found = suite.keeper.Get(suite.ctx, other)
+	require.True(t, found)
 }
 
 func TestProviderDeleteNonExisting(t *testing.T) {
@@ -267,3 +550,65 @@ func TestProviderDeleteNonExisting(t *testing.T) {
 	require.Nil(t, res)
 	require.True(t, errors.Is(err, types.ErrProviderNotFound))
 }
+
+func (st *testSuite) createProvider() sdk.AccAddress {
+	st.t.Helper()
+
+	owner := testutil.AccAddress(st.t)
+
+	err := st.keeper.Create(st.ctx, types.Provider{
+		Owner:   owner.String(),
+		HostURI: testutil.ProviderHostname(st.t),
+	})
+	require.NoError(st.t, err)
+
+	return owner
+}
+
+func (st *testSuite) createLease(provider sdk.AccAddress) (mtypes.LeaseID, mtypes.Bid, mtypes.Order) {
+	st.t.Helper()
+	bid, order := st.createBid(provider)
+
+	lid := mtypes.MakeLeaseID(bid.ID())
+	aid := dtypes.EscrowAccountForDeployment(lid.DeploymentID())
+
+	owner, err := sdk.AccAddressFromBech32(lid.Owner)
+	require.NoError(st.t, err)
+
+	err = st.ekeeper.AccountCreate(st.ctx, aid, owner, owner, defaultDeposit)
+	require.NoError(st.t, err)
+
+	err = st.ekeeper.PaymentCreate(st.ctx, aid, mtypes.EscrowPaymentForLease(lid), provider, bid.Price)
+	require.NoError(st.t, err)
+
+	st.mkeeper.CreateLease(st.ctx, bid)
+	st.mkeeper.OnBidMatched(st.ctx, bid)
+	st.mkeeper.OnOrderMatched(st.ctx, order)
+
+	bid.State = mtypes.BidActive
+	order.State = mtypes.OrderActive
+
+	return lid, bid, order
+}
+
+func (st *testSuite) createBid(provider sdk.AccAddress) (mtypes.Bid, mtypes.Order) {
+	st.t.Helper()
+
+	deployment := testutil.Deployment(st.t)
+	group := testutil.DeploymentGroup(st.t, deployment.ID(), 0)
+	group.GroupSpec.Resources = testutil.Resources(st.t)
+
+	err := st.dkeeper.Create(st.ctx, deployment, []dtypes.Group{group})
+	require.NoError(st.t, err)
+
+	order, err := st.mkeeper.CreateOrder(st.ctx, group.ID(), group.GroupSpec)
+	require.NoError(st.t, err)
+
+	price := sdk.NewDecCoin(testutil.CoinDenom, sdk.NewInt(int64(rand.Uint16())))
+	roffer := mtypes.ResourceOfferFromRU(group.GroupSpec.Resources)
+
+	bid, err := st.mkeeper.CreateBid(st.ctx, order.ID(), provider, price, roffer)
+	require.NoError(st.t, err)
+
+	return bid, order
+}
@@ -7,26 +7,38 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 
+	dtypes "github.com/akash-network/akash-api/go/node/deployment/v1beta3"
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
 	types "github.com/akash-network/akash-api/go/node/provider/v1beta3"
+	akashtypes "github.com/akash-network/akash-api/go/node/types/v1beta3"
 
+	"github.com/akash-network/node/util/attributes"
 	mkeeper "github.com/akash-network/node/x/market/keeper"
 	"github.com/akash-network/node/x/provider/keeper"
 )
 
+// attributesMode is the attribute registry mode applied to provider messages.
+// Rejecting attributes that existing providers already advertise would break
+// consensus with previous releases, so registry violations are only logged on chain.
+// Strict checking is done client side, see provider create/update commands.
+const attributesMode = attributes.ModeWarn
+
 var (
 	// ErrInternal defines registered error code for internal error
 	ErrInternal = sdkerrors.Register(types.ModuleName, 10, "internal error")
 )
 
 type msgServer struct {
-	provider keeper.IKeeper
-	market   mkeeper.IKeeper
+	provider   keeper.IKeeper
+	market     mkeeper.IKeeper
+	escrow     EscrowKeeper
+	deployment DeploymentKeeper
 }
 
 // NewMsgServerImpl returns an implementation of the market MsgServer interface
 // for the provided Keeper.
-func NewMsgServerImpl(k keeper.IKeeper, mk mkeeper.IKeeper) types.MsgServer {
-	return &msgServer{provider: k, market: mk}
+func NewMsgServerImpl(k keeper.IKeeper, mk mkeeper.IKeeper, ek EscrowKeeper, dk DeploymentKeeper) types.MsgServer {
+	return &msgServer{provider: k, market: mk, escrow: ek, deployment: dk}
 }
 
 var _ types.MsgServer = msgServer{}
@@ -38,6 +50,10 @@ func (ms msgServer) CreateProvider(goCtx context.Context, msg *types.MsgCreatePr
 		return nil, err
 	}
 
+	if err := checkAttributes(ctx, msg.Owner, msg.Attributes); err != nil {
+		return nil, err
+	}
+
 	owner, _ := sdk.AccAddressFromBech32(msg.Owner)
 
 	if _, ok := ms.provider.Get(ctx, owner); ok {
@@ -59,6 +75,10 @@ func (ms msgServer) UpdateProvider(goCtx context.Context, msg *types.MsgUpdatePr
 		return nil, err
 	}
 
+	if err := checkAttributes(ctx, msg.Owner, msg.Attributes); err != nil {
+		return nil, err
+	}
+
 	owner, _ := sdk.AccAddressFromBech32(msg.Owner)
 	_, found := ms.provider.Get(ctx, owner)
 	if !found {
@@ -84,6 +104,77 @@ func (ms msgServer) DeleteProvider(goCtx context.Context, msg *types.MsgDeletePr
 		return nil, types.ErrProviderNotFound
 	}
 
-	// TODO: cancel leases
-	return nil, sdkerrors.Wrapf(ErrInternal, "NOTIMPLEMENTED")
+	// collect bids first as closing them mutates the provider index being iterated
+	var bids []mtypes.Bid
+
+	ms.market.WithBidsForProvider(ctx, owner, mtypes.BidOpen, func(bid mtypes.Bid) bool {
+		bids = append(bids, bid)
+		return false
+	})
+
+	ms.market.WithBidsForProvider(ctx, owner, mtypes.BidActive, func(bid mtypes.Bid) bool {
+		bids = append(bids, bid)
+		return false
+	})
+
+	for _, bid := range bids {
+		if err := ms.closeBid(ctx, bid); err != nil {
+			return nil, sdkerrors.Wrapf(ErrInternal, "bid %s: %v", bid.ID(), err)
+		}
+	}
+
+	ms.provider.Delete(ctx, owner)
+
+	return &types.MsgDeleteProviderResponse{}, nil
+}
+
+// checkAttributes validates provider attributes against the well-known attribute registry
+func checkAttributes(ctx sdk.Context, owner string, attrs akashtypes.Attributes) error {
+	warnings, err := attributes.Validate(attrs, attributesMode)
+	if err != nil {
+		return sdkerrors.Wrap(types.ErrAttributes, err.Error())
+	}
+
+	for _, warning := range warnings {
+		ctx.Logger().Info("provider attribute does not match registry", "provider", owner, "err", warning)
+	}
+
+	return nil
+}
+
+// closeBid tears down bid and its lease (if any) the same way provider initiated MsgCloseBid does
+func (ms msgServer) closeBid(ctx sdk.Context, bid mtypes.Bid) error {
+	if bid.State == mtypes.BidOpen {
+		ms.market.OnBidClosed(ctx, bid)
+		return nil
+	}
+
+	order, found := ms.market.GetOrder(ctx, bid.ID().OrderID())
+	if !found {
+		return mtypes.ErrUnknownOrderForBid
+	}
+
+	lease, found := ms.market.GetLease(ctx, bid.ID().LeaseID())
+	if !found {
+		return mtypes.ErrUnknownLeaseForBid
+	}
+
+	if lease.State == mtypes.LeaseActive {
+		if err := ms.deployment.OnBidClosed(ctx, order.ID().GroupID()); err != nil {
+			return err
+		}
+	}
+
+	ms.market.OnLeaseClosed(ctx, lease, mtypes.LeaseClosed)
+	ms.market.OnBidClosed(ctx, bid)
+	ms.market.OnOrderClosed(ctx, order)
+
+	// same as MsgCloseBid, payment which can not be closed does not block bid teardown
+	if err := ms.escrow.PaymentClose(ctx,
+		dtypes.EscrowAccountForDeployment(lease.ID().DeploymentID()),
+		mtypes.EscrowPaymentForLease(lease.ID())); err != nil {
+		ctx.Logger().Error("closing lease payment", "err", err, "lease", lease.ID())
+	}
+
+	return nil
 }
@@ -0,0 +1,12 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+)
+
+type MarketKeeper interface {
+	WithBids(ctx sdk.Context, fn func(mtypes.Bid) bool)
+	WithLeases(ctx sdk.Context, fn func(mtypes.Lease) bool)
+}
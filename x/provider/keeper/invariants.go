@@ -0,0 +1,70 @@
+package keeper
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	mtypes "github.com/akash-network/akash-api/go/node/market/v1beta4"
+	types "github.com/akash-network/akash-api/go/node/provider/v1beta3"
+)
+
+const (
+	invariantProviderExists = "provider-exists"
+)
+
+// RegisterInvariants registers the provider module invariants
+func RegisterInvariants(ir sdk.InvariantRegistry, k IKeeper, mk MarketKeeper) {
+	ir.RegisterRoute(types.ModuleName, invariantProviderExists, ProviderExistsInvariant(k, mk))
+}
+
+// ProviderExistsInvariant checks that provider of every open or active bid and active lease exists
+func ProviderExistsInvariant(k IKeeper, mk MarketKeeper) sdk.Invariant {
+	return func(ctx sdk.Context) (string, bool) {
+		var (
+			msg   string
+			count int
+		)
+
+		exists := func(provider string) bool {
+			addr, err := sdk.AccAddressFromBech32(provider)
+			if err != nil {
+				return false
+			}
+
+			_, found := k.Get(ctx, addr)
+			return found
+		}
+
+		mk.WithBids(ctx, func(bid mtypes.Bid) bool {
+			if bid.State != mtypes.BidOpen && bid.State != mtypes.BidActive {
+				return false
+			}
+
+			if !exists(bid.ID().Provider) {
+				count++
+				msg += fmt.Sprintf("\tbid %s in state %s has unknown provider\n", bid.ID(), bid.State)
+			}
+
+			return false
+		})
+
+		mk.WithLeases(ctx, func(lease mtypes.Lease) bool {
+			if lease.State != mtypes.LeaseActive {
+				return false
+			}
+
+			if !exists(lease.ID().Provider) {
+				count++
+				msg += fmt.Sprintf("\tactive lease %s has unknown provider\n", lease.ID())
+			}
+
+			return false
+		})
+
+		broken := count != 0
+
+		return sdk.FormatInvariant(types.ModuleName, invariantProviderExists,
+			fmt.Sprintf("amount of bids and leases with unknown provider found %d\n%s", count, msg)), broken
+	}
+}
@@ -122,5 +122,16 @@ func (k Keeper) Update(ctx sdk.Context, provider types.Provider) error {
 
 // Delete delete a provider
 func (k Keeper) Delete(ctx sdk.Context, id sdk.Address) {
-	panic("TODO")
+	store := ctx.KVStore(k.skey)
+	key := ProviderKey(id)
+
+	if !store.Has(key) {
+		return
+	}
+
+	store.Delete(key)
+
+	ctx.EventManager().EmitEvent(
+		types.EventProviderDeleted{Owner: sdk.AccAddress(id.Bytes())}.ToSDKEvent(),
+	)
 }
@@ -111,16 +111,20 @@ type AppModule struct {
 	keeper  keeper.IKeeper
 	bkeeper bankkeeper.Keeper
 	mkeeper mkeeper.IKeeper
+	ekeeper handler.EscrowKeeper
+	dkeeper handler.DeploymentKeeper
 }
 
 // NewAppModule creates a new AppModule object
 func NewAppModule(cdc codec.Codec, k keeper.IKeeper, bkeeper bankkeeper.Keeper,
-	mkeeper mkeeper.IKeeper) AppModule {
+	mkeeper mkeeper.IKeeper, ekeeper handler.EscrowKeeper, dkeeper handler.DeploymentKeeper) AppModule {
 	return AppModule{
 		AppModuleBasic: AppModuleBasic{cdc: cdc},
 		keeper:         k,
 		bkeeper:        bkeeper,
 		mkeeper:        mkeeper,
+		ekeeper:        ekeeper,
+		dkeeper:        dkeeper,
 	}
 }
 
@@ -130,11 +134,13 @@ func (AppModule) Name() string {
 }
 
 // RegisterInvariants registers module invariants
-func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {}
+func (am AppModule) RegisterInvariants(ir sdk.InvariantRegistry) {
+	keeper.RegisterInvariants(ir, am.keeper, am.mkeeper)
+}
 
 // Route returns the message routing key for the provider module.
 func (am AppModule) Route() sdk.Route {
-	return sdk.NewRoute(types.RouterKey, handler.NewHandler(am.keeper, am.mkeeper))
+	return sdk.NewRoute(types.RouterKey, handler.NewHandler(am.keeper, am.mkeeper, am.ekeeper, am.dkeeper))
 }
 
 // QuerierRoute returns the provider module's querier route name.
@@ -149,7 +155,7 @@ func (am AppModule) LegacyQuerierHandler(_ *codec.LegacyAmino) sdk.Querier {
 
 // RegisterServices registers the module's services
 func (am AppModule) RegisterServices(cfg module.Configurator) {
-	types.RegisterMsgServer(cfg.MsgServer(), handler.NewMsgServerImpl(am.keeper, am.mkeeper))
+	types.RegisterMsgServer(cfg.MsgServer(), handler.NewMsgServerImpl(am.keeper, am.mkeeper, am.ekeeper, am.dkeeper))
 	querier := am.keeper.NewQuerier()
 	types.RegisterQueryServer(cfg.QueryServer(), querier)
 }
@@ -0,0 +1,78 @@
+package cli
+
+import (
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	types "github.com/akash-network/akash-api/go/node/take/v1beta3"
+
+	"github.com/akash-network/node/x/take/query"
+)
+
+// GetQueryCmd returns the query commands for the take module
+func GetQueryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Take query commands",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       sdkclient.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		cmdGetParams(),
+		cmdGetFeesTaken(),
+	)
+
+	return cmd
+}
+
+func cmdGetParams() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "params",
+		Short: "Query current take rates",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := sdkclient.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			res, err := query.NewClient(cctx, types.StoreKey).Params()
+			if err != nil {
+				return err
+			}
+
+			return cctx.PrintProto(&res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
+
+func cmdGetFeesTaken() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fees-taken",
+		Short: "Query total amount of fees taken per denom",
+		Args:  cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cctx, err := sdkclient.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			res, err := query.NewClient(cctx, types.StoreKey).FeesTaken()
+			if err != nil {
+				return err
+			}
+
+			return cctx.PrintObjectLegacy(res)
+		},
+	}
+
+	flags.AddQueryFlagsToCmd(cmd)
+
+	return cmd
+}
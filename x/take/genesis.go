@@ -1,6 +1,9 @@
 package take
 
 import (
+	"encoding/json"
+
+	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -10,29 +13,86 @@ import (
 	"github.com/akash-network/node/x/take/keeper"
 )
 
+// GenesisState of the take module. Take genesis of the API carries params only,
+// cumulative ledger of fees taken is kept next to them.
+type GenesisState struct {
+	Params    types.Params
+	FeesTaken sdk.Coins
+}
+
+// genesisStateJSON is the JSON layout of GenesisState. Params are encoded
+// with the codec to keep the layout of the API genesis.
+type genesisStateJSON struct {
+	Params    json.RawMessage `json:"params"`
+	FeesTaken sdk.Coins       `json:"fees_taken,omitempty"`
+}
+
+// MarshalGenesis encodes genesis state as JSON
+func MarshalGenesis(cdc codec.JSONCodec, data *GenesisState) (json.RawMessage, error) {
+	params, err := cdc.MarshalJSON(&data.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(genesisStateJSON{
+		Params:    params,
+		FeesTaken: data.FeesTaken,
+	})
+}
+
+// UnmarshalGenesis decodes genesis state from JSON. Genesis of the API is accepted as is
+func UnmarshalGenesis(cdc codec.JSONCodec, bz json.RawMessage) (*GenesisState, error) {
+	var raw genesisStateJSON
+
+	if err := json.Unmarshal(bz, &raw); err != nil {
+		return nil, err
+	}
+
+	data := &GenesisState{
+		FeesTaken: raw.FeesTaken,
+	}
+
+	if len(raw.Params) != 0 {
+		if err := cdc.UnmarshalJSON(raw.Params, &data.Params); err != nil {
+			return nil, err
+		}
+	}
+
+	return data, nil
+}
+
 // ValidateGenesis does validation check of the Genesis and return error incase of failure
-func ValidateGenesis(data *types.GenesisState) error {
-	return data.Params.Validate()
+func ValidateGenesis(data *GenesisState) error {
+	if err := data.Params.Validate(); err != nil {
+		return err
+	}
+
+	return data.FeesTaken.Validate()
 }
 
 // DefaultGenesisState returns default genesis state as raw bytes for the deployment
 // module.
-func DefaultGenesisState() *types.GenesisState {
-	return &types.GenesisState{
+func DefaultGenesisState() *GenesisState {
+	return &GenesisState{
 		Params: types.DefaultParams(),
 	}
 }
 
 // InitGenesis initiate genesis state and return updated validator details
-func InitGenesis(ctx sdk.Context, keeper keeper.IKeeper, data *types.GenesisState) []abci.ValidatorUpdate {
+func InitGenesis(ctx sdk.Context, keeper keeper.IKeeper, data *GenesisState) []abci.ValidatorUpdate {
 	keeper.SetParams(ctx, data.Params)
+
+	for _, fee := range data.FeesTaken {
+		keeper.AddFeesTaken(ctx, fee)
+	}
+
 	return []abci.ValidatorUpdate{}
 }
 
 // ExportGenesis returns genesis state for the deployment module
-func ExportGenesis(ctx sdk.Context, k keeper.IKeeper) *types.GenesisState {
-	params := k.GetParams(ctx)
-	return &types.GenesisState{
-		Params: params,
+func ExportGenesis(ctx sdk.Context, k keeper.IKeeper) *GenesisState {
+	return &GenesisState{
+		Params:    k.GetParams(ctx),
+		FeesTaken: k.GetFeesTaken(ctx),
 	}
 }
@@ -15,6 +15,8 @@ type IKeeper interface {
 	GetParams(ctx sdk.Context) (params types.Params)
 	SetParams(ctx sdk.Context, params types.Params)
 	SubtractFees(ctx sdk.Context, amt sdk.Coin) (sdk.Coin, sdk.Coin, error)
+	AddFeesTaken(ctx sdk.Context, fee sdk.Coin)
+	GetFeesTaken(ctx sdk.Context) sdk.Coins
 }
 
 // Keeper of the deployment store
@@ -70,6 +72,55 @@ func (k Keeper) SubtractFees(ctx sdk.Context, amt sdk.Coin) (sdk.Coin, sdk.Coin,
 	return earnings, sdk.NewCoin(amt.GetDenom(), fees), nil
 }
 
+// AddFeesTaken adds fee to the cumulative per denom ledger of taken fees.
+// Should be called by SubtractFees callers once fee has been transferred.
+func (k Keeper) AddFeesTaken(ctx sdk.Context, fee sdk.Coin) {
+	if fee.IsZero() {
+		return
+	}
+
+	store := ctx.KVStore(k.skey)
+	key := feesTakenKey(fee.Denom)
+
+	total := sdk.ZeroInt()
+
+	if buf := store.Get(key); buf != nil {
+		if err := total.Unmarshal(buf); err != nil {
+			panic(err)
+		}
+	}
+
+	buf, err := total.Add(fee.Amount).Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	store.Set(key, buf)
+}
+
+// GetFeesTaken returns cumulative amount of fees taken for each denom
+func (k Keeper) GetFeesTaken(ctx sdk.Context) sdk.Coins {
+	store := ctx.KVStore(k.skey)
+	iter := sdk.KVStorePrefixIterator(store, FeesTakenPrefix)
+
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	res := sdk.NewCoins()
+
+	for ; iter.Valid(); iter.Next() {
+		var amount sdk.Int
+		if err := amount.Unmarshal(iter.Value()); err != nil {
+			panic(err)
+		}
+
+		res = res.Add(sdk.NewCoin(string(iter.Key()[len(FeesTakenPrefix):]), amount))
+	}
+
+	return res
+}
+
 func (k Keeper) findRate(ctx sdk.Context, denom string) sdk.Dec {
 	params := k.GetParams(ctx)
 
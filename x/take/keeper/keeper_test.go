@@ -0,0 +1,83 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "github.com/akash-network/akash-api/go/node/take/v1beta3"
+
+	"github.com/akash-network/node/testutil"
+	"github.com/akash-network/node/testutil/state"
+	"github.com/akash-network/node/x/take"
+)
+
+func Test_SubtractFees(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+	ctx, keeper := suite.Context(), suite.TakeKeeper()
+
+	params := types.DefaultParams()
+	params.DenomTakeRates = types.DenomTakeRates{
+		{Denom: "uakt", Rate: 2},
+		{Denom: "ibc/usdc", Rate: 20},
+	}
+	keeper.SetParams(ctx, params)
+
+	earnings, fee, err := keeper.SubtractFees(ctx, sdk.NewInt64Coin("uakt", 1000))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin("uakt", 980), earnings)
+	require.Equal(t, sdk.NewInt64Coin("uakt", 20), fee)
+
+	earnings, fee, err = keeper.SubtractFees(ctx, sdk.NewInt64Coin("ibc/usdc", 1000))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewInt64Coin("ibc/usdc", 800), earnings)
+	require.Equal(t, sdk.NewInt64Coin("ibc/usdc", 200), fee)
+}
+
+func Test_FeesTaken(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+	ctx, keeper := suite.Context(), suite.TakeKeeper()
+
+	require.True(t, keeper.GetFeesTaken(ctx).IsZero())
+
+	keeper.AddFeesTaken(ctx, testutil.AkashCoin(t, 20))
+	keeper.AddFeesTaken(ctx, sdk.NewInt64Coin("ibc/usdc", 200))
+	keeper.AddFeesTaken(ctx, testutil.AkashCoin(t, 5))
+	keeper.AddFeesTaken(ctx, testutil.AkashCoin(t, 0))
+
+	require.Equal(t, sdk.NewCoins(
+		testutil.AkashCoin(t, 25),
+		sdk.NewInt64Coin("ibc/usdc", 200),
+	), keeper.GetFeesTaken(ctx))
+}
+
+func Test_FeesTakenGenesis(t *testing.T) {
+	suite := state.SetupTestSuite(t)
+	ctx, keeper := suite.Context(), suite.TakeKeeper()
+	cdc := suite.App().AppCodec()
+
+	keeper.SetParams(ctx, types.DefaultParams())
+	keeper.AddFeesTaken(ctx, testutil.AkashCoin(t, 25))
+	keeper.AddFeesTaken(ctx, sdk.NewInt64Coin("ibc/usdc", 200))
+
+	bz, err := take.MarshalGenesis(cdc, take.ExportGenesis(ctx, keeper))
+	require.NoError(t, err)
+
+	data, err := take.UnmarshalGenesis(cdc, bz)
+	require.NoError(t, err)
+	require.NoError(t, take.ValidateGenesis(data))
+
+	imported := state.SetupTestSuite(t)
+	take.InitGenesis(imported.Context(), imported.TakeKeeper(), data)
+
+	require.Equal(t, keeper.GetParams(ctx), imported.TakeKeeper().GetParams(imported.Context()))
+	require.Equal(t, keeper.GetFeesTaken(ctx), imported.TakeKeeper().GetFeesTaken(imported.Context()))
+
+	// genesis of the API carries no fees taken
+	data, err = take.UnmarshalGenesis(cdc, cdc.MustMarshalJSON(&types.GenesisState{Params: types.DefaultParams()}))
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultParams(), data.Params)
+	require.True(t, data.FeesTaken.IsZero())
+}
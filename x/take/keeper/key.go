@@ -0,0 +1,14 @@
+package keeper
+
+var (
+	// FeesTakenPrefix stores cumulative amount of fees taken, keyed by denom
+	FeesTakenPrefix = []byte{0x01}
+)
+
+func feesTakenKey(denom string) []byte {
+	key := make([]byte, 0, len(FeesTakenPrefix)+len(denom))
+	key = append(key, FeesTakenPrefix...)
+	key = append(key, denom...)
+
+	return key
+}
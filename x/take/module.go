@@ -19,7 +19,9 @@ import (
 	"github.com/gogo/protobuf/grpc"
 	"github.com/pkg/errors"
 
+	"github.com/akash-network/node/x/take/client/cli"
 	"github.com/akash-network/node/x/take/keeper"
+	"github.com/akash-network/node/x/take/query"
 	"github.com/akash-network/node/x/take/simulation"
 
 	types "github.com/akash-network/akash-api/go/node/take/v1beta3"
@@ -54,7 +56,12 @@ func (b AppModuleBasic) RegisterInterfaces(registry cdctypes.InterfaceRegistry)
 // DefaultGenesis returns default genesis state as raw bytes for the provider
 // module.
 func (AppModuleBasic) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
-	return cdc.MustMarshalJSON(DefaultGenesisState())
+	bz, err := MarshalGenesis(cdc, DefaultGenesisState())
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
 }
 
 // ValidateGenesis validation check of the Genesis
@@ -63,14 +70,12 @@ func (AppModuleBasic) ValidateGenesis(cdc codec.JSONCodec, _ client.TxEncodingCo
 		return nil
 	}
 
-	var data types.GenesisState
-
-	err := cdc.UnmarshalJSON(bz, &data)
+	data, err := UnmarshalGenesis(cdc, bz)
 	if err != nil {
 		return errors.Errorf("failed to unmarshal %s genesis state: %v", types.ModuleName, err)
 	}
 
-	return ValidateGenesis(&data)
+	return ValidateGenesis(data)
 }
 
 // RegisterRESTRoutes registers rest routes for this module
@@ -87,7 +92,7 @@ func (AppModuleBasic) RegisterGRPCRoutes(clientCtx client.Context, mux *runtime.
 
 // GetQueryCmd returns the root query command of this module
 func (AppModuleBasic) GetQueryCmd() *cobra.Command {
-	return nil
+	return cli.GetQueryCmd()
 }
 
 // GetTxCmd returns the transaction commands for this module
@@ -134,7 +139,7 @@ func (am AppModule) QuerierRoute() string {
 
 // LegacyQuerierHandler returns the sdk.Querier for take module
 func (am AppModule) LegacyQuerierHandler(legacyQuerierCdc *codec.LegacyAmino) sdk.Querier {
-	return nil
+	return query.NewQuerier(am.keeper, legacyQuerierCdc)
 }
 
 // RegisterServices registers the module's servicess
@@ -158,16 +163,23 @@ func (am AppModule) EndBlock(ctx sdk.Context, _ abci.RequestEndBlock) []abci.Val
 // InitGenesis performs genesis initialization for the take module. It returns
 // no validator updates.
 func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, data json.RawMessage) []abci.ValidatorUpdate {
-	var genesisState types.GenesisState
-	cdc.MustUnmarshalJSON(data, &genesisState)
-	return InitGenesis(ctx, am.keeper, &genesisState)
+	genesisState, err := UnmarshalGenesis(cdc, data)
+	if err != nil {
+		panic(err)
+	}
+
+	return InitGenesis(ctx, am.keeper, genesisState)
 }
 
 // ExportGenesis returns the exported genesis state as raw bytes for the take
 // module.
 func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.RawMessage {
-	gs := ExportGenesis(ctx, am.keeper)
-	return cdc.MustMarshalJSON(gs)
+	bz, err := MarshalGenesis(cdc, ExportGenesis(ctx, am.keeper))
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
 }
 
 // ConsensusVersion implements module.AppModule#ConsensusVersion
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"fmt"
+
+	sdkclient "github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	types "github.com/akash-network/akash-api/go/node/take/v1beta3"
+)
+
+// Client interface
+type Client interface {
+	Params() (types.Params, error)
+	FeesTaken() (sdk.Coins, error)
+}
+
+// NewClient creates a client instance with provided context and key
+func NewClient(ctx sdkclient.Context, key string) Client {
+	return &client{ctx: ctx, key: key}
+}
+
+type client struct {
+	ctx sdkclient.Context
+	key string
+}
+
+func (c *client) Params() (types.Params, error) {
+	var obj types.Params
+	err := c.query(ParamsPath, &obj)
+	return obj, err
+}
+
+func (c *client) FeesTaken() (sdk.Coins, error) {
+	var obj sdk.Coins
+	err := c.query(FeesTakenPath, &obj)
+	return obj, err
+}
+
+func (c *client) query(path string, obj interface{}) error {
+	buf, _, err := c.ctx.QueryWithData(fmt.Sprintf("custom/%s/%s", c.key, path), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.ctx.LegacyAmino.UnmarshalJSON(buf, obj)
+}